@@ -0,0 +1,6 @@
+#!/bin/bash
+greet() {
+  echo "Hello, $1"
+}
+
+greet world
@@ -0,0 +1,79 @@
+package languages
+
+import (
+	"time"
+)
+
+// Activity levels of the drivers.
+const (
+	activityActive = "active"
+	activitySlow   = "slow"
+	activityStale  = "stale"
+)
+
+// ActivityThresholds are the thresholds of the activity heuristic.
+type ActivityThresholds struct {
+	// Active and Slow are the maximum time since the last commit or release
+	// of an active and a slow driver. Older drivers are stale.
+	Active time.Duration
+	Slow   time.Duration
+	// IssueGrowth is the growth of the open issues in the runs of the
+	// history that makes an active driver slow, as they are not attended.
+	IssueGrowth int
+}
+
+// Activity are the thresholds used by the activity column, that may be
+// changed before rendering.
+var Activity = ActivityThresholds{
+	Active:      90 * 24 * time.Hour,
+	Slow:        365 * 24 * time.Hour,
+	IssueGrowth: 10,
+}
+
+// activity returns how active the driver is, from its last commit and
+// release and the growth of its open issues, or an empty string if it is
+// unknown. Unlike the status of the manifest, it is not self-reported.
+func (t ActivityThresholds) activity(d Driver, now time.Time) string {
+	last := d.LastCommit
+	if d.ReleaseDate != nil && (last == nil || d.ReleaseDate.After(*last)) {
+		last = d.ReleaseDate
+	}
+	if last == nil {
+		return ""
+	}
+	switch age := now.Sub(*last); {
+	case age > t.Slow:
+		return activityStale
+	case age > t.Active:
+		return activitySlow
+	case t.IssueGrowth > 0 && d.IssueGrowth >= t.IssueGrowth:
+		return activitySlow
+	}
+	return activityActive
+}
+
+func activityCell(f Renderer, d Driver) string {
+	switch a := Activity.activity(d, time.Now()); a {
+	case activityActive:
+		return f.Badge(a, colorGreen)
+	case activitySlow:
+		return f.Badge(a, colorYellow)
+	case activityStale:
+		return f.Badge(a, colorRed)
+	}
+	return f.Text("-")
+}
+
+// SetIssueGrowth sets the growth of the open issues of the drivers since
+// the oldest run of the history that includes them.
+func SetIssueGrowth(list []Driver, hist []HistoryEntry) {
+	for i := range list {
+		d := &list[i]
+		for _, e := range hist {
+			if n, ok := e.Issues[d.Language]; ok {
+				d.IssueGrowth = d.OpenIssues - n
+				break
+			}
+		}
+	}
+}
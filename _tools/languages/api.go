@@ -0,0 +1,133 @@
+package languages
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// apiVersion is the version of the schema of the static API. It must be
+// increased on changes that are not backward compatible.
+const apiVersion = 1
+
+// apiIndex is the content of drivers/index.json in the static API.
+type apiIndex struct {
+	Version int              `json:"version"`
+	Drivers []apiIndexDriver `json:"drivers"`
+}
+
+// apiIndexDriver is the entry of a driver in the index of the static API.
+type apiIndexDriver struct {
+	Language string   `json:"language"`
+	Name     string   `json:"name"`
+	Status   string   `json:"status"`
+	Features []string `json:"features"`
+	// Image is empty if the driver has no published image.
+	Image string `json:"image"`
+	// URL is the path of the details of the driver, relative to the index.
+	URL string `json:"url"`
+}
+
+// apiDriver is the content of drivers/<language>.json in the static API.
+type apiDriver struct {
+	Version     int             `json:"version"`
+	Language    string          `json:"language"`
+	Name        string          `json:"name"`
+	Aliases     []string        `json:"aliases"`
+	Extensions  []string        `json:"extensions"`
+	Status      string          `json:"status"`
+	Features    []string        `json:"features"`
+	Community   bool            `json:"community"`
+	Repository  string          `json:"repository"`
+	Image       string          `json:"image"`
+	Latest      string          `json:"latest_version"`
+	SDKVersion  string          `json:"sdk_version"`
+	Protocols   []string        `json:"protocols"`
+	License     string          `json:"license"`
+	Maintainers []apiMaintainer `json:"maintainers"`
+}
+
+type apiMaintainer struct {
+	Name   string `json:"name"`
+	Github string `json:"github,omitempty"`
+	Email  string `json:"email,omitempty"`
+}
+
+// WriteAPI writes a static JSON API of the drivers into dir, with an index
+// of all the drivers in drivers/index.json and their details in
+// drivers/<language>.json. Lists are never null, so clients can rely on
+// the schema.
+func WriteAPI(dir string, list []Driver) error {
+	dir = filepath.Join(dir, "drivers")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	index := apiIndex{Version: apiVersion, Drivers: make([]apiIndexDriver, 0, len(list))}
+	tasks := make([]func() error, 0, len(list))
+	for _, d := range list {
+		ad := newAPIDriver(d)
+		index.Drivers = append(index.Drivers, apiIndexDriver{
+			Language: ad.Language,
+			Name:     ad.Name,
+			Status:   ad.Status,
+			Features: ad.Features,
+			Image:    ad.Image,
+			URL:      d.Language + ".json",
+		})
+		path := filepath.Join(dir, d.Language+".json")
+		tasks = append(tasks, func() error { return writeJSON(path, ad) })
+	}
+	if err := RunParallel(0, tasks...); err != nil {
+		return err
+	}
+	return writeJSON(filepath.Join(dir, "index.json"), index)
+}
+
+func newAPIDriver(d Driver) apiDriver {
+	name := d.Name
+	if name == "" {
+		name = d.Language
+	}
+	ad := apiDriver{
+		Version:     apiVersion,
+		Language:    d.Language,
+		Name:        name,
+		Aliases:     nonNil(d.Aliases),
+		Extensions:  nonNil(d.Extensions),
+		Status:      string(d.Status),
+		Features:    []string{},
+		Community:   d.Community,
+		Repository:  d.RepoURL(),
+		Latest:      d.LatestVersion,
+		SDKVersion:  d.SDKVersion,
+		Protocols:   nonNil(d.Protocols),
+		License:     d.License,
+		Maintainers: make([]apiMaintainer, 0, len(d.Maintainers)),
+	}
+	if d.DockerhubURL != "" {
+		ad.Image = d.Image
+	}
+	for _, ft := range d.Features {
+		ad.Features = append(ad.Features, string(ft))
+	}
+	for _, m := range d.Maintainers {
+		ad.Maintainers = append(ad.Maintainers, apiMaintainer{Name: m.Name, Github: m.Github, Email: m.Email})
+	}
+	return ad
+}
+
+func nonNil(list []string) []string {
+	if list == nil {
+		return []string{}
+	}
+	return list
+}
+
+func writeJSON(path string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "\t")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, append(data, '\n'), 0644)
+}
@@ -0,0 +1,111 @@
+package languages
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// Documentation artifacts required in the driver repositories.
+const (
+	artifactReadme       = "README"
+	artifactUsage        = "Usage section"
+	artifactLicense      = "LICENSE"
+	artifactContributing = "CONTRIBUTING"
+	artifactExamples     = "Examples"
+)
+
+// auditArtifacts are the artifacts checked by AuditDriver, in the order of
+// the report.
+var auditArtifacts = []string{artifactReadme, artifactUsage, artifactLicense, artifactContributing, artifactExamples}
+
+// usageHeading matches a markdown heading about the usage of the driver.
+var usageHeading = regexp.MustCompile(`(?im)^#+\s.*\b(usage|getting started)\b`)
+
+// Audit is the result of checking the documentation artifacts of a driver
+// repository.
+type Audit struct {
+	Driver Driver
+	// Missing are the artifacts not found in the repository.
+	Missing []string
+}
+
+// AuditDriver checks that the repository of the driver has a README with
+// a usage section, a LICENSE, a CONTRIBUTING guide and an examples
+// directory, as the driver documentation standard requires. Only
+// repositories in GitHub can be audited.
+func AuditDriver(d Driver) (Audit, error) {
+	a := Audit{Driver: d}
+	if d.GithubURL == "" {
+		return a, fmt.Errorf("%s: only drivers in GitHub can be audited", d.Language)
+	}
+	g := newGithub()
+	repo := repoPath(&d)
+	var files []struct {
+		Name string `json:"name"`
+		Type string `json:"type"`
+	}
+	if err := g.get("repos/"+repo+"/contents/", &files); err != nil {
+		return a, err
+	}
+
+	found := make(map[string]bool)
+	var readme string
+	for _, f := range files {
+		name := strings.ToUpper(f.Name)
+		switch {
+		case f.Type == "dir" && (name == "EXAMPLES" || name == "_EXAMPLES"):
+			found[artifactExamples] = true
+		case f.Type != "file":
+		case strings.HasPrefix(name, "README"):
+			found[artifactReadme] = true
+			readme = f.Name
+		case strings.HasPrefix(name, "LICENSE"), strings.HasPrefix(name, "COPYING"):
+			found[artifactLicense] = true
+		case strings.HasPrefix(name, "CONTRIBUTING"):
+			found[artifactContributing] = true
+		}
+	}
+	if readme != "" {
+		data, err := g.rawFile(repo, "HEAD", readme)
+		if err != nil {
+			return a, err
+		}
+		found[artifactUsage] = usageHeading.Match(data)
+	}
+	for _, art := range auditArtifacts {
+		if !found[art] {
+			a.Missing = append(a.Missing, art)
+		}
+	}
+	return a, nil
+}
+
+// WriteAudit writes the documentation compliance report of the drivers.
+func WriteAudit(w io.Writer, f Renderer, audits []Audit) {
+	fmt.Fprint(w, f.Header())
+	defer fmt.Fprint(w, f.Footer())
+
+	compliant := 0
+	rows := make([][]string, 0, len(audits))
+	for _, a := range audits {
+		if len(a.Missing) == 0 {
+			compliant++
+		}
+		missing := make(map[string]bool, len(a.Missing))
+		for _, art := range a.Missing {
+			missing[art] = true
+		}
+		row := []string{f.Link(a.Driver.Language, a.Driver.RepoURL())}
+		for _, art := range auditArtifacts {
+			row = append(row, f.Mark(!missing[art], ""))
+		}
+		rows = append(rows, row)
+	}
+
+	f.Heading(w, "Driver documentation")
+	f.Paragraph(w, f.Text(fmt.Sprintf("%d of %s have all the documentation required by the driver documentation standard.",
+		compliant, plural(len(audits), "driver"))))
+	f.Table(w, append([]string{"Language"}, auditArtifacts...), rows)
+}
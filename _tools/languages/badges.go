@@ -0,0 +1,69 @@
+package languages
+
+import (
+	"fmt"
+	"html"
+
+	"gopkg.in/bblfsh/sdk.v1/manifest"
+)
+
+// colorGrey is the color of the badges of unknown or inactive values.
+const colorGrey = "#9f9f9f"
+
+// badge is a static badge, like the ones of shields.io.
+type badge struct {
+	label, message, color string
+}
+
+// badgeCharWidth is the approximate width of a character of the badges,
+// as they are rendered with an 11px sans-serif font.
+const badgeCharWidth = 7
+
+// svg renders the badge as a standalone SVG image. The output only depends
+// on the badge, so the files do not change if the drivers do not.
+func (b badge) svg() []byte {
+	lw := len(b.label)*badgeCharWidth + 10
+	mw := len(b.message)*badgeCharWidth + 10
+	w := lw + mw
+	label, msg := html.EscapeString(b.label), html.EscapeString(b.message)
+	return []byte(fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="20" role="img" aria-label="%s: %s">
+<title>%s: %s</title>
+<rect width="%d" height="20" fill="#555"/>
+<rect x="%d" width="%d" height="20" fill="%s"/>
+<g fill="#fff" text-anchor="middle" font-family="Verdana,DejaVu Sans,sans-serif" font-size="11">
+<text x="%d" y="14">%s</text>
+<text x="%d" y="14">%s</text>
+</g>
+</svg>
+`, w, label, msg, label, msg, lw, lw, mw, b.color, lw/2, label, lw+mw/2, msg))
+}
+
+// statusColor returns the color of the badge of a development status.
+func statusColor(st manifest.DevelopmentStatus) string {
+	switch r := st.Rank(); {
+	case r >= manifest.Beta.Rank():
+		return colorGreen
+	case r >= manifest.Alpha.Rank():
+		return colorYellow
+	case st == "" || st == manifest.Inactive:
+		return colorGrey
+	}
+	return colorRed
+}
+
+// Badges returns SVG badges of the status and UAST support of each driver,
+// by file name, like "go-status.svg" and "go-uast.svg". The names are
+// stable, so the files can be committed and linked from the drivers.
+func Badges(list []Driver) map[string][]byte {
+	files := make(map[string][]byte, 2*len(list))
+	for _, d := range list {
+		status := badge{label: "bblfsh " + d.Language, message: orDash(string(d.Status)), color: statusColor(d.Status)}
+		uast := badge{label: "uast", message: "no", color: colorRed}
+		if d.Supports(manifest.UAST) {
+			uast.message, uast.color = "yes", colorGreen
+		}
+		files[d.Language+"-status.svg"] = status.svg()
+		files[d.Language+"-uast.svg"] = uast.svg()
+	}
+	return files
+}
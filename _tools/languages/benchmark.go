@@ -0,0 +1,155 @@
+package languages
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	bblfsh "gopkg.in/bblfsh/client-go.v2"
+)
+
+// benchmarkRounds is the number of times the corpus is parsed, to smooth
+// the measures.
+const benchmarkRounds = 3
+
+// Performance are the measures of parsing a corpus with a driver image.
+type Performance struct {
+	// Files and Bytes are the size of the corpus.
+	Files int
+	Bytes int64
+	// Duration is the mean time to parse the whole corpus.
+	Duration time.Duration
+	// Memory is the memory used by the driver container after parsing the
+	// corpus, in bytes.
+	Memory int64 `json:",omitempty"`
+}
+
+// Throughput returns the parsed bytes per second.
+func (p Performance) Throughput() float64 {
+	if p.Duration <= 0 {
+		return 0
+	}
+	return float64(p.Bytes) / p.Duration.Seconds()
+}
+
+// benchmarks measure the performance of the published image of each driver
+// parsing a corpus of files. It needs a local Docker daemon.
+type benchmarks struct {
+	// dir holds a directory of files for each language, named after the
+	// language key.
+	dir string
+}
+
+func newBenchmarks(dir string) *benchmarks {
+	return &benchmarks{dir: dir}
+}
+
+type corpusFile struct {
+	name, src string
+}
+
+// loadPerformance parses the corpus of the driver language with its latest
+// image. A failure is logged and leaves the performance empty.
+func (b *benchmarks) loadPerformance(d *Driver) {
+	if d.DockerhubURL == "" {
+		return
+	}
+	paths, err := filepath.Glob(filepath.Join(b.dir, d.Language, "*"))
+	if err != nil || len(paths) == 0 {
+		return
+	}
+	var (
+		files []corpusFile
+		size  int64
+	)
+	for _, path := range paths {
+		src, err := ioutil.ReadFile(path)
+		if err != nil {
+			enrichFailed("benchmark", "cannot read corpus of %s: %v", d.Language, err)
+			return
+		}
+		files = append(files, corpusFile{name: filepath.Base(path), src: string(src)})
+		size += int64(len(src))
+	}
+
+	c, err := startDriver(d.Image + ":" + d.RecommendedTag())
+	if err != nil {
+		enrichFailed("benchmark", "cannot start %s: %v", d.Image, err)
+		return
+	}
+	defer c.stop()
+	// the first parse waits for the driver, and is not measured
+	if _, err := c.waitParse(d.Language, files[0].name, files[0].src); err != nil {
+		enrichFailed("benchmark", "cannot parse corpus with %s: %v", d.Image, err)
+		return
+	}
+
+	cli, err := bblfsh.NewClient(c.addr)
+	if err != nil {
+		enrichFailed("benchmark", "cannot connect to %s: %v", d.Image, err)
+		return
+	}
+	defer cli.Close()
+	start := time.Now()
+	for i := 0; i < benchmarkRounds; i++ {
+		for _, f := range files {
+			res, err := cli.NewParseRequest().Language(d.Language).Filename(f.name).Content(f.src).Do()
+			if err == nil && len(res.Errors) != 0 {
+				err = fmt.Errorf("%s", strings.Join(res.Errors, "; "))
+			}
+			if err != nil {
+				enrichFailed("benchmark", "cannot parse %s with %s: %v", f.name, d.Image, err)
+				return
+			}
+		}
+	}
+	perf := &Performance{
+		Files:    len(files),
+		Bytes:    size,
+		Duration: time.Since(start) / benchmarkRounds,
+	}
+	if perf.Memory, err = c.memory(); err != nil {
+		enrichFailed("benchmark", "cannot get memory of %s: %v", d.Image, err)
+	}
+	d.Performance = perf
+}
+
+// memory returns the memory used by the container, as reported by
+// docker stats.
+func (c *driverContainer) memory() (int64, error) {
+	out, err := exec.Command("docker", "stats", "--no-stream", "--format", "{{.MemUsage}}", c.id).Output()
+	if err != nil {
+		return 0, fmt.Errorf("docker stats: %v", err)
+	}
+	// like "12.5MiB / 1.952GiB"
+	usage := strings.TrimSpace(strings.SplitN(string(out), "/", 2)[0])
+	return parseDockerSize(usage)
+}
+
+// dockerUnits are the binary units used by docker stats.
+var dockerUnits = []struct {
+	suffix string
+	size   float64
+}{
+	{"KiB", 1 << 10},
+	{"MiB", 1 << 20},
+	{"GiB", 1 << 30},
+	{"B", 1},
+}
+
+func parseDockerSize(s string) (int64, error) {
+	for _, u := range dockerUnits {
+		if strings.HasSuffix(s, u.suffix) {
+			v, err := strconv.ParseFloat(strings.TrimSuffix(s, u.suffix), 64)
+			if err != nil {
+				return 0, err
+			}
+			return int64(v * u.size), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown size: %q", s)
+}
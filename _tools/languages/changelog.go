@@ -0,0 +1,117 @@
+package languages
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/bblfsh/sdk.v1/manifest/discovery"
+)
+
+// Release is a GitHub release of a driver.
+type Release struct {
+	Language    string    `json:"-"`
+	TagName     string    `json:"tag_name"`
+	Name        string    `json:"name"`
+	URL         string    `json:"html_url"`
+	Body        string    `json:"body"`
+	Draft       bool      `json:"draft"`
+	PublishedAt time.Time `json:"published_at"`
+}
+
+// Releases returns the releases of the official drivers published after
+// since, or after the time in last for the language of the driver if it
+// is later. Drafts are excluded.
+func Releases(ctx context.Context, since time.Time, last map[string]time.Time) ([]Release, error) {
+	langs, err := discovery.OfficialDrivers(ctx, &discovery.Options{NamesOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	gh := newGithub()
+	var rels []Release
+	for _, d := range langs {
+		repo := strings.TrimPrefix(d.RepositoryURL(), "https://github.com/")
+		list, err := gh.releases(repo)
+		if err != nil {
+			log.Printf("cannot list releases of %s: %v", repo, err)
+			continue
+		}
+		after := since
+		if t, ok := last[d.Language]; ok && t.After(after) {
+			after = t
+		}
+		for _, r := range list {
+			if r.Draft || !r.PublishedAt.After(after) {
+				continue
+			}
+			r.Language = d.Language
+			rels = append(rels, r)
+		}
+	}
+	return rels, nil
+}
+
+// releases returns the most recent releases of a GitHub repository.
+func (g *githubClient) releases(repo string) ([]Release, error) {
+	var list []Release
+	err := g.get("repos/"+repo+"/releases?per_page=100", &list)
+	if err == errNotFound {
+		return nil, nil
+	}
+	return list, err
+}
+
+// WriteChangelog writes the "Driver updates" page, with the newest
+// releases first.
+func WriteChangelog(w io.Writer, f Renderer, rels []Release) {
+	sort.SliceStable(rels, func(i, j int) bool {
+		return rels[i].PublishedAt.After(rels[j].PublishedAt)
+	})
+
+	fmt.Fprint(w, f.Header())
+	defer fmt.Fprint(w, f.Footer())
+
+	f.Heading(w, "Driver updates")
+	if len(rels) == 0 {
+		f.Paragraph(w, f.Text("No driver has been released in this period."))
+		return
+	}
+	var month string
+	for _, r := range rels {
+		if m := r.PublishedAt.Format("January 2006"); m != month {
+			month = m
+			f.Subheading(w, month)
+		}
+		title := r.Language + " " + r.TagName
+		if r.Name != "" && r.Name != r.TagName {
+			title += " - " + r.Name
+		}
+		f.Paragraph(w, f.Link(title, r.URL)+f.Text(" ("+r.PublishedAt.Format("2006-01-02")+")"))
+		writeNotes(w, f, r.Body)
+	}
+}
+
+var mdHeading = regexp.MustCompile(`(?m)^#{1,4} `)
+
+// writeNotes writes the release notes of a driver. In markdown they are
+// copied as they are, only demoting their headings below the ones of the
+// page, and in other formats they are written as preformatted text.
+func writeNotes(w io.Writer, f Renderer, body string) {
+	body = strings.TrimSpace(strings.Replace(body, "\r\n", "\n", -1))
+	if body == "" {
+		return
+	}
+	if _, ok := f.(Markdown); !ok {
+		f.Code(w, "", body)
+		return
+	}
+	body = mdHeading.ReplaceAllStringFunc(body, func(s string) string {
+		return "##" + s
+	})
+	f.Paragraph(w, body)
+}
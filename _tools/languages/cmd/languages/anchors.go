@@ -0,0 +1,124 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+var (
+	headingLine = regexp.MustCompile(`^\s{0,3}(#{1,6})\s+(.*?)\s*#*\s*$`)
+	// explicit anchors: <a name="x"> or id="x"
+	htmlAnchor = regexp.MustCompile(`<[^>]+\s(?:name|id)\s*=\s*["']([^"']+)["']`)
+	linkText   = regexp.MustCompile(`!?\[([^\]]*)\]\([^)]*\)`)
+)
+
+// anchorIndex caches the anchors defined by each markdown file.
+type anchorIndex struct {
+	root    string
+	anchors map[string]map[string]bool
+}
+
+func newAnchorIndex(root string) *anchorIndex {
+	return &anchorIndex{root: root, anchors: make(map[string]map[string]bool)}
+}
+
+// check validates that the fragment of an internal link points to an
+// anchor of its target file. Links to files other than markdown are not
+// checked.
+func (x *anchorIndex) check(l docLink) error {
+	_, frag := splitFragment(l.URL)
+	target := resolveLink(l)
+	if frag == "" {
+		return nil
+	}
+	if strings.HasSuffix(target, ".html") {
+		target = strings.TrimSuffix(target, ".html") + ".md"
+	}
+	if filepath.Ext(target) != ".md" {
+		return nil
+	}
+
+	anchors, ok := x.anchors[target]
+	if !ok {
+		var err error
+		anchors, err = fileAnchors(filepath.Join(x.root, filepath.FromSlash(target)))
+		if os.IsNotExist(err) {
+			// already reported as a missing file
+			return nil
+		} else if err != nil {
+			return err
+		}
+		x.anchors[target] = anchors
+	}
+	if !anchors[strings.ToLower(frag)] {
+		return fmt.Errorf("%s has no heading for #%s", target, frag)
+	}
+	return nil
+}
+
+// fileAnchors returns the anchors of a markdown file: the slugs of its
+// headings, as generated by GitBook and GitHub, and explicit HTML anchors.
+func fileAnchors(path string) (map[string]bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var (
+		anchors = make(map[string]bool)
+		seen    = make(map[string]int)
+		fence   string
+	)
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		line := sc.Text()
+		if trimmed := strings.TrimSpace(line); fence != "" {
+			if strings.HasPrefix(trimmed, fence) {
+				fence = ""
+			}
+			continue
+		} else if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			fence = trimmed[:3]
+			continue
+		}
+
+		for _, m := range htmlAnchor.FindAllStringSubmatch(line, -1) {
+			anchors[strings.ToLower(m[1])] = true
+		}
+		m := headingLine.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		slug := slugify(m[2])
+		// duplicated headings get a numeric suffix
+		if n := seen[slug]; n > 0 {
+			anchors[fmt.Sprintf("%s-%d", slug, n)] = true
+		} else {
+			anchors[slug] = true
+		}
+		seen[slug]++
+	}
+	return anchors, sc.Err()
+}
+
+// slugify returns the anchor of a heading: lowercase text, with spaces
+// turned into dashes and punctuation removed.
+func slugify(heading string) string {
+	heading = linkText.ReplaceAllString(heading, "$1")
+	var buf strings.Builder
+	for _, r := range strings.ToLower(strings.TrimSpace(heading)) {
+		switch {
+		case unicode.IsLetter(r), unicode.IsDigit(r), r == '-', r == '_':
+			buf.WriteRune(r)
+		case unicode.IsSpace(r):
+			buf.WriteRune('-')
+		}
+	}
+	return buf.String()
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/bblfsh/documentation/_tools/languages"
+)
+
+// runAudit implements the audit subcommand, that checks the documentation
+// artifacts of the repositories of the drivers selected with the flags of
+// the main command and writes a compliance report. Missing artifacts are
+// warnings, or errors of the official drivers with -strict.
+func runAudit(args []string) error {
+	fs := flag.NewFlagSet("audit", flag.ExitOnError)
+	out := fs.String("o", "md", "output format (md or html)")
+	strict := fs.Bool("strict", false, "report the missing artifacts of the official drivers as errors")
+	fs.Parse(args)
+
+	f, ok := languages.Renderers[*out]
+	if !ok {
+		return fmt.Errorf("unknown output format: %q", *out)
+	}
+	list, err := languages.Discover(runCtx, discoverOptions())
+	if err != nil {
+		return err
+	}
+
+	audits := make([]languages.Audit, 0, len(list))
+	for _, d := range list {
+		a, err := languages.AuditDriver(d)
+		if err != nil {
+			reportWarning(languages.Problem{Msg: fmt.Sprintf("cannot audit %s: %v", d.Language, err)})
+			continue
+		}
+		audits = append(audits, a)
+		if len(a.Missing) == 0 {
+			continue
+		}
+		report := reportWarning
+		if *strict && !d.Community {
+			report = reportError
+		}
+		report(languages.Problem{Msg: d.Language + ": missing " + strings.Join(a.Missing, ", ")})
+	}
+	languages.WriteAudit(os.Stdout, f, audits)
+	return nil
+}
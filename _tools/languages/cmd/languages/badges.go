@@ -0,0 +1,78 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/bblfsh/documentation/_tools/languages"
+)
+
+// runBadges implements the badges subcommand, that writes SVG badges of
+// the drivers selected with the flags of the main command, to be committed
+// and linked from the READMEs of the drivers. With -check, it reports the
+// badges that are not up to date instead.
+func runBadges(args []string) error {
+	fs := flag.NewFlagSet("badges", flag.ExitOnError)
+	dir := fs.String("out-dir", "badges", "directory to write the badges to")
+	checkOnly := fs.Bool("check", false, "check that the badges in -out-dir are up to date instead of writing them")
+	fs.Parse(args)
+
+	list, err := loadDrivers(false, nil)
+	if err != nil {
+		return err
+	}
+	badges := languages.Badges(list)
+	if *checkOnly {
+		return checkBadges(*dir, badges)
+	}
+	if err := os.MkdirAll(*dir, 0755); err != nil {
+		return err
+	}
+	var tasks []func() error
+	for name, data := range badges {
+		path, data := filepath.Join(*dir, name), data
+		tasks = append(tasks, func() error { return ioutil.WriteFile(path, data, 0644) })
+	}
+	return languages.RunParallel(0, tasks...)
+}
+
+// checkBadges reports the badges in dir that differ from the expected
+// ones, are missing, or belong to drivers that no longer exist.
+func checkBadges(dir string, badges map[string][]byte) error {
+	names := make([]string, 0, len(badges))
+	for name := range badges {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	stale := 0
+	for _, name := range names {
+		path := filepath.Join(dir, name)
+		data, err := ioutil.ReadFile(path)
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		if !bytes.Equal(data, badges[name]) {
+			reportError(languages.Problem{File: path, Msg: "not up to date, run 'make languages'"})
+			stale++
+		}
+	}
+	files, err := filepath.Glob(filepath.Join(dir, "*.svg"))
+	if err != nil {
+		return err
+	}
+	for _, path := range files {
+		if _, ok := badges[filepath.Base(path)]; !ok {
+			reportError(languages.Problem{File: path, Msg: "badge of an unknown driver, remove it"})
+			stale++
+		}
+	}
+	if stale != 0 {
+		return errStale
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/bblfsh/documentation/_tools/languages"
+)
+
+// runChangelog implements the changelog subcommand, that writes a page
+// with the release notes of all the drivers, grouped by month.
+func runChangelog(args []string) error {
+	fs := flag.NewFlagSet("changelog", flag.ExitOnError)
+	since := fs.String("since", "", "only include releases published after this date (YYYY-MM-DD)")
+	snapshot := fs.String("snapshot", "", "JSON output of a previous run; only releases newer than the ones in it are included")
+	out := fs.String("o", "md", "output format (md or html)")
+	fs.Parse(args)
+
+	f, ok := languages.Renderers[*out]
+	if !ok {
+		return fmt.Errorf("unknown output format: %q", *out)
+	}
+	var from time.Time
+	if *since != "" {
+		t, err := time.Parse("2006-01-02", *since)
+		if err != nil {
+			return fmt.Errorf("invalid -since: %v", err)
+		}
+		from = t
+	}
+	last := make(map[string]time.Time)
+	if *snapshot != "" {
+		var err error
+		if last, err = loadSnapshot(*snapshot); err != nil {
+			return err
+		}
+	}
+
+	rels, err := languages.Releases(runCtx, from, last)
+	if err != nil {
+		return err
+	}
+	log.Println(len(rels), "driver releases found")
+
+	languages.WriteChangelog(os.Stdout, f, rels)
+	return nil
+}
+
+// loadSnapshot returns the date of the latest release of each driver in
+// a previous JSON output of the tool.
+func loadSnapshot(path string) (map[string]time.Time, error) {
+	list, err := languages.ReadDrivers(path)
+	if err != nil {
+		return nil, err
+	}
+	last := make(map[string]time.Time, len(list))
+	for _, d := range list {
+		if d.ReleaseDate != nil {
+			last[d.Language] = *d.ReleaseDate
+		}
+	}
+	return last, nil
+}
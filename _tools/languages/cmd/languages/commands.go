@@ -0,0 +1,102 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+)
+
+// command is a subcommand of the tool. The flags of the main command are
+// shared by all of them, and go before the name of the subcommand.
+type command struct {
+	name    string
+	summary string
+	run     func(args []string) error
+}
+
+// commands are the subcommands of the tool, in the order of the usage.
+var commands = []command{
+	{"generate", "write the document of the drivers (the default)", runGenerate},
+	{"check", "check that a generated document is up to date", runCheckCommand},
+	{"diff", "write the changes between two JSON outputs", runCompare},
+	{"orgs", "compare the drivers of two GitHub organizations, like upstream and a fork", runOrgs},
+	{"validate", "check the manifests of the drivers and write a quality report", runValidate},
+	{"audit", "check the documentation of the driver repositories and write a compliance report", runAudit},
+	{"serve", "serve the table of drivers over HTTP, refreshing it periodically", runServe},
+	{"stale-images", "report the driver images in Docker Hub without a driver", runStaleImages},
+	{"badges", "write SVG badges of the drivers", runBadges},
+	{"changelog", "write the release notes of the drivers, grouped by month", runChangelog},
+	{"linkcheck", "check the links of the documentation", runLinkcheck},
+	{"toc", "update the table of contents of the book", runTOC},
+	{"search", "write the search index of the documentation", runSearch},
+	{"orphans", "report the pages not reachable from the entry pages of the book", runOrphans},
+	{"spell", "check the spelling of the documentation", runSpell},
+	{"snippets", "check the code blocks of the documentation", runSnippets},
+}
+
+// commandAliases are the former names of the subcommands.
+var commandAliases = map[string]string{
+	"":        "generate",
+	"compare": "diff",
+}
+
+// lookupCommand returns the subcommand with the given name or alias.
+func lookupCommand(name string) (command, error) {
+	if alias, ok := commandAliases[name]; ok {
+		name = alias
+	}
+	for _, c := range commands {
+		if c.name == name {
+			return c, nil
+		}
+	}
+	return command{}, fmt.Errorf("unknown command: %q", name)
+}
+
+func usage() {
+	w := flag.CommandLine.Output()
+	fmt.Fprintf(w, "usage: %s [flags] [command] [args]\n\ncommands:\n", os.Args[0])
+	for _, c := range commands {
+		fmt.Fprintf(w, "  %-12s %s\n", c.name, c.summary)
+	}
+	fmt.Fprint(w, "\nRun a command with -h for its arguments. The flags, shared by all the commands, are:\n\n")
+	flag.PrintDefaults()
+}
+
+// runGenerate implements the generate subcommand, that writes the document
+// selected with the flags.
+func runGenerate(args []string) error {
+	fs := flag.NewFlagSet("generate", flag.ExitOnError)
+	fs.Parse(args)
+	switch {
+	case *watch && *output == "":
+		return fmt.Errorf("-watch requires -out")
+	case *watch:
+		return runWatch(*output, *interval)
+	case *createPR && *output == "":
+		return fmt.Errorf("-create-pr requires -out")
+	case *createPR:
+		return runCreatePR(*output)
+	case *check != "":
+		// the former way to run the check subcommand
+		return runCheck(*check)
+	case *output != "":
+		_, err := writeOutput(*output)
+		return err
+	}
+	_, err := run(os.Stdout)
+	return err
+}
+
+// runCheckCommand implements the check subcommand, that reports the
+// document as stale if it differs from the one generated with the flags.
+func runCheckCommand(args []string) error {
+	fs := flag.NewFlagSet("check", flag.ExitOnError)
+	fs.Parse(args)
+	if fs.NArg() != 1 {
+		return fmt.Errorf("usage: check languages.md")
+	}
+	// the outputs other than the document are not written while checking
+	*check = fs.Arg(0)
+	return runCheck(*check)
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/bblfsh/documentation/_tools/languages"
+)
+
+// runCompare implements the diff subcommand, that writes a report of
+// the changes between two JSON outputs of the tool.
+func runCompare(args []string) error {
+	fs := flag.NewFlagSet("diff", flag.ExitOnError)
+	out := fs.String("o", "md", "output format (md or html)")
+	fs.Parse(args)
+	if fs.NArg() != 2 {
+		return fmt.Errorf("usage: diff [-o md|html] old.json new.json")
+	}
+
+	f, ok := languages.Renderers[*out]
+	if !ok {
+		return fmt.Errorf("unknown output format: %q", *out)
+	}
+	old, err := languages.ReadDrivers(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	cur, err := languages.ReadDrivers(fs.Arg(1))
+	if err != nil {
+		return err
+	}
+	languages.WriteChanges(os.Stdout, f, languages.DiffDrivers(old, cur))
+	return nil
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/bblfsh/documentation/_tools/languages"
+)
+
+// runCreatePR generates the document into path, along with the other
+// outputs selected with the flags, and opens a pull request with them if
+// they differ from the committed ones. The repository must be a clone of
+// -pr-repo with a remote that can be pushed to.
+func runCreatePR(path string) error {
+	list, err := writeOutput(path)
+	if err != nil {
+		return err
+	}
+	files, err := changedFiles(outputPaths(path))
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		log.Println("nothing changed, no pull request opened")
+		return nil
+	}
+
+	changes, err := committedChanges(path, list)
+	if err != nil {
+		return err
+	}
+
+	title := "Update the list of drivers"
+	branch := "languages-" + time.Now().UTC().Format("20060102-150405")
+	if err := gitCmd("checkout", "-b", branch); err != nil {
+		return err
+	}
+	for _, args := range [][]string{
+		append([]string{"add", "--"}, files...),
+		{"commit", "-m", title},
+		{"push", *prRemote, branch},
+	} {
+		if err := gitCmd(args...); err != nil {
+			// back to the original branch, without the one created
+			if cerr := gitCmd("checkout", "-"); cerr != nil {
+				log.Println(cerr)
+			} else if derr := gitCmd("branch", "-D", branch); derr != nil {
+				log.Println(derr)
+			}
+			return err
+		}
+	}
+	if err := gitCmd("checkout", "-"); err != nil {
+		return err
+	}
+
+	url, err := languages.OpenPullRequest(languages.PullRequest{
+		Repo:  *prRepo,
+		Base:  *prBase,
+		Head:  branch,
+		Title: title,
+		Body:  languages.PullRequestBody(files, changes),
+	})
+	if err != nil {
+		return err
+	}
+	log.Println("pull request opened:", url)
+	return nil
+}
+
+// committedChanges returns the changes of the drivers since the document
+// committed at path, for the description of the pull request. A document
+// that is not committed yet has all the drivers as new.
+func committedChanges(path string, list []languages.Driver) ([]languages.Change, error) {
+	var old []languages.Driver
+	out, err := exec.Command("git", "show", "HEAD:./"+filepath.ToSlash(path)).Output()
+	if err == nil {
+		old = languages.ReadDocumentDrivers(out)
+		statuses.Unlabel(old)
+	} else if _, ok := err.(*exec.ExitError); !ok {
+		return nil, fmt.Errorf("git show: %v", err)
+	}
+	return languages.DiffDrivers(old, list), nil
+}
+
+// outputPaths returns the files and directories written with the flags.
+func outputPaths(path string) []string {
+	paths := []string{path}
+	for _, p := range []string{*pagesDir, *apiDir, *index} {
+		if p != "" {
+			paths = append(paths, p)
+		}
+	}
+	return paths
+}
+
+// changedFiles returns the files under paths that differ from the
+// committed ones, including the new ones.
+func changedFiles(paths []string) ([]string, error) {
+	args := append([]string{"status", "--porcelain", "--untracked-files=all", "--"}, paths...)
+	out, err := exec.Command("git", args...).Output()
+	if err != nil {
+		return nil, fmt.Errorf("git status: %v", err)
+	}
+	var files []string
+	for _, line := range strings.Split(string(out), "\n") {
+		// like " M languages.md" or "?? languages/go.md"
+		if len(line) > 3 {
+			files = append(files, line[3:])
+		}
+	}
+	return files, nil
+}
+
+func gitCmd(args ...string) error {
+	out, err := exec.Command("git", args...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("git %s: %v: %s", args[0], err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
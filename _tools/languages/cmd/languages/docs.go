@@ -0,0 +1,94 @@
+package main
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// defaultExclude are the paths of the documentation that are not part of
+// the published book.
+const defaultExclude = "proposals/drafts/*"
+
+// walkDocs returns the markdown files under root, relative to it and with
+// forward slashes, skipping hidden and underscore-prefixed directories
+// (like .git, _book or _tools), node_modules and the paths matching any of
+// the exclude globs.
+func walkDocs(root string, exclude []string) ([]string, error) {
+	var files []string
+	err := filepath.Walk(root, func(path string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+
+		name := fi.Name()
+		if fi.IsDir() {
+			if rel != "." && (strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") || name == "node_modules") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if filepath.Ext(name) != ".md" {
+			return nil
+		}
+		for _, pattern := range exclude {
+			if ok, _ := filepath.Match(pattern, rel); ok {
+				return nil
+			}
+		}
+		files = append(files, rel)
+		return nil
+	})
+	return files, err
+}
+
+// splitList splits a comma-separated flag value, ignoring empty items.
+func splitList(s string) []string {
+	var out []string
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// docTitle returns the first heading of a markdown file, or an empty
+// string if it has none.
+func docTitle(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	var prev string
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		switch {
+		case strings.HasPrefix(line, "#"):
+			return strings.TrimSpace(strings.Trim(line, "#")), nil
+		case prev != "" && line != "" && strings.Trim(line, "=") == "":
+			// setext heading
+			return prev, nil
+		}
+		prev = line
+	}
+	return "", sc.Err()
+}
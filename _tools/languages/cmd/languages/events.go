@@ -0,0 +1,44 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bblfsh/documentation/_tools/languages"
+)
+
+// eventLog is the kind of the events of the log lines.
+const eventLog = "log"
+
+var events struct {
+	sync.Mutex
+	enc *json.Encoder
+}
+
+// enableEvents writes the events of the run to stderr as JSON lines,
+// including the log lines and the problems, so every line of stderr can
+// be decoded.
+func enableEvents() {
+	events.enc = json.NewEncoder(os.Stderr)
+	languages.Events = writeEvent
+	log.SetFlags(0)
+	log.SetOutput(logEvents{})
+}
+
+func writeEvent(e languages.Event) {
+	events.Lock()
+	defer events.Unlock()
+	events.enc.Encode(e)
+}
+
+// logEvents turns the log lines into events.
+type logEvents struct{}
+
+func (logEvents) Write(p []byte) (int, error) {
+	writeEvent(languages.Event{Time: time.Now().UTC(), Kind: eventLog, Msg: strings.TrimSuffix(string(p), "\n")})
+	return len(p), nil
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/bblfsh/documentation/_tools/languages"
+)
+
+// Exit codes of the tool.
+const (
+	exitOK = 0
+	// exitError is a failure to generate the output, or problems found by
+	// the checks.
+	exitError = 1
+	// exitStale is a generated file that is not up to date, in the check
+	// modes.
+	exitStale = 2
+	// exitPartial is a successful run where some details of the drivers
+	// could not be loaded, or the drivers were listed from the -fallback.
+	exitPartial = 3
+)
+
+// exitCode returns the exit code of a run that ended with err.
+func exitCode(err error) int {
+	switch {
+	case err == errStale:
+		return exitStale
+	case err != nil:
+		return exitError
+	case degradedReason() != "" || len(languages.EnrichFailures()) != 0:
+		return exitPartial
+	}
+	return exitOK
+}
+
+// printSummary writes the errors and enrichment failures of the run,
+// grouped by source, if there were any.
+func printSummary(w io.Writer) {
+	failures := languages.EnrichFailures()
+	reported.Lock()
+	defer reported.Unlock()
+	if len(reported.errors) == 0 && len(failures) == 0 {
+		return
+	}
+
+	fmt.Fprintf(w, "\nsummary: %d errors, %d warnings, %d enrichment failures\n",
+		len(reported.errors), reported.count[levelWarning], len(failures))
+	if len(reported.errors) != 0 {
+		fmt.Fprintf(w, "  problems (%d):\n", len(reported.errors))
+		for _, p := range reported.errors {
+			fmt.Fprintf(w, "    %s\n", p.Error())
+		}
+	}
+	var sources []string
+	bySource := make(map[string][]string)
+	for _, f := range failures {
+		if _, ok := bySource[f.Source]; !ok {
+			sources = append(sources, f.Source)
+		}
+		bySource[f.Source] = append(bySource[f.Source], f.Msg)
+	}
+	for _, src := range sources {
+		fmt.Fprintf(w, "  %s (%d):\n", src, len(bySource[src]))
+		for _, msg := range bySource[src] {
+			fmt.Fprintf(w, "    %s\n", msg)
+		}
+	}
+}
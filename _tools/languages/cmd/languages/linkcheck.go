@@ -0,0 +1,197 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bblfsh/documentation/_tools/languages"
+)
+
+// runLinkcheck implements the linkcheck subcommand, that validates the
+// links of every markdown file of the documentation. Internal links must
+// point to existing files, and to existing headings if they have a
+// fragment. External links must respond successfully.
+func runLinkcheck(args []string) error {
+	fs := flag.NewFlagSet("linkcheck", flag.ExitOnError)
+	root := fs.String("root", ".", "root directory of the documentation")
+	exclude := fs.String("exclude", "", "comma-separated globs of files not to check")
+	external := fs.Bool("external", true, "check external links too")
+	anchors := fs.Bool("anchors", true, "check that fragments of internal links point to existing headings")
+	concurrency := fs.Int("concurrency", 8, "maximum number of concurrent requests")
+	retries := fs.Int("retries", 2, "number of retries for failed requests")
+	timeout := fs.Duration("timeout", 30*time.Second, "timeout of each request")
+	fs.Parse(args)
+
+	files, err := walkDocs(*root, splitList(*exclude))
+	if err != nil {
+		return err
+	}
+	var links []docLink
+	for _, f := range files {
+		l, err := extractLinks(*root, f)
+		if err != nil {
+			return err
+		}
+		links = append(links, l...)
+	}
+
+	var broken []brokenLink
+	byURL := make(map[string][]docLink)
+	index := newAnchorIndex(*root)
+	for _, l := range links {
+		if !isExternal(l.URL) {
+			err := checkInternal(*root, l)
+			if err == nil && *anchors {
+				err = index.check(l)
+			}
+			if err != nil {
+				broken = append(broken, brokenLink{docLink: l, Err: err})
+			}
+			continue
+		}
+		if *external && isHTTP(l.URL) {
+			byURL[l.URL] = append(byURL[l.URL], l)
+		}
+	}
+
+	if len(byURL) != 0 {
+		log.Println("checking", len(byURL), "external links")
+		c := &urlChecker{
+			cli:     languages.NewHTTPClient(*timeout),
+			retries: *retries,
+		}
+		for url, err := range c.checkAll(byURL, *concurrency) {
+			for _, l := range byURL[url] {
+				broken = append(broken, brokenLink{docLink: l, Err: err})
+			}
+		}
+	}
+
+	return reportBroken(broken)
+}
+
+type brokenLink struct {
+	docLink
+	Err error
+}
+
+// reportBroken prints the broken links sorted by location, and returns an
+// error if there is any.
+func reportBroken(broken []brokenLink) error {
+	sort.Slice(broken, func(i, j int) bool {
+		a, b := broken[i], broken[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		return a.Line < b.Line
+	})
+	for _, b := range broken {
+		reportError(languages.Problem{File: b.File, Line: b.Line, Msg: fmt.Sprintf("%s: %v", b.URL, b.Err)})
+	}
+	if len(broken) != 0 {
+		return fmt.Errorf("%d broken links found", len(broken))
+	}
+	return nil
+}
+
+func checkInternal(root string, l docLink) error {
+	p := resolveLink(l)
+	if _, err := os.Stat(filepath.Join(root, filepath.FromSlash(p))); os.IsNotExist(err) && strings.HasSuffix(p, ".html") {
+		// GitBook renders every markdown file as an HTML page
+		p = strings.TrimSuffix(p, ".html") + ".md"
+	}
+	if _, err := os.Stat(filepath.Join(root, filepath.FromSlash(p))); os.IsNotExist(err) {
+		return fmt.Errorf("%s does not exist", p)
+	} else if err != nil {
+		return err
+	}
+	return nil
+}
+
+func isHTTP(url string) bool {
+	return len(url) > 7 && (url[:7] == "http://" || (len(url) > 8 && url[:8] == "https://"))
+}
+
+// urlChecker checks that external URLs respond successfully.
+type urlChecker struct {
+	cli     *http.Client
+	retries int
+}
+
+// checkAll checks every URL in the map, with at most n concurrent
+// requests, and returns the ones that failed.
+func (c *urlChecker) checkAll(urls map[string][]docLink, n int) map[string]error {
+	var (
+		mu     sync.Mutex
+		failed = make(map[string]error)
+
+		wg sync.WaitGroup
+		// limits the number of concurrent requests
+		tokens = make(chan struct{}, n)
+	)
+	for url := range urls {
+		wg.Add(1)
+		go func(url string) {
+			defer wg.Done()
+
+			tokens <- struct{}{}
+			defer func() {
+				<-tokens
+			}()
+
+			if err := c.check(url); err != nil {
+				mu.Lock()
+				failed[url] = err
+				mu.Unlock()
+			}
+		}(url)
+	}
+	wg.Wait()
+	return failed
+}
+
+// check requests the URL, retrying on network errors, rate limiting and
+// server errors.
+func (c *urlChecker) check(url string) error {
+	var err error
+	for i := 0; i <= c.retries; i++ {
+		if i > 0 {
+			time.Sleep(time.Duration(i) * time.Second)
+		}
+		var retry bool
+		if retry, err = c.try(url); err == nil || !retry {
+			return err
+		}
+	}
+	return err
+}
+
+func (c *urlChecker) try(url string) (retry bool, _ error) {
+	resp, err := c.cli.Head(url)
+	if err == nil && (resp.StatusCode == http.StatusMethodNotAllowed || resp.StatusCode == http.StatusForbidden) {
+		// some servers do not support HEAD requests
+		resp.Body.Close()
+		resp, err = c.cli.Get(url)
+	}
+	if err != nil {
+		return true, err
+	}
+	resp.Body.Close()
+
+	switch {
+	case resp.StatusCode < 400:
+		return false, nil
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return true, fmt.Errorf("unexpected status: %s", resp.Status)
+	default:
+		return false, fmt.Errorf("unexpected status: %s", resp.Status)
+	}
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"bufio"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// docLink is a link found in a markdown file.
+type docLink struct {
+	// File is the path of the markdown file, relative to the root.
+	File string
+	Line int
+	URL  string
+}
+
+var (
+	// inline links and images: [text](url "title")
+	inlineLink = regexp.MustCompile(`!?\[[^\]]*\]\(\s*<?([^)\s>]+)>?(?:\s+["'][^"']*["'])?\s*\)`)
+	// reference definitions: [id]: url
+	refLink = regexp.MustCompile(`^\s{0,3}\[[^\]]+\]:\s*<?([^\s>]+)>?`)
+	// autolinks: <http://...>
+	autoLink = regexp.MustCompile(`<(https?://[^>\s]+)>`)
+	// links in inline HTML
+	htmlLink = regexp.MustCompile(`(?:href|src)\s*=\s*["']([^"']+)["']`)
+
+	codeSpan = regexp.MustCompile("`+[^`]*`+")
+)
+
+// extractLinks returns the links in a markdown file, ignoring the ones in
+// code blocks and code spans.
+func extractLinks(root, file string) ([]docLink, error) {
+	f, err := os.Open(filepath.Join(root, filepath.FromSlash(file)))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var (
+		links []docLink
+		fence string
+		n     int
+	)
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		n++
+		line := sc.Text()
+		if trimmed := strings.TrimSpace(line); fence != "" {
+			if strings.HasPrefix(trimmed, fence) {
+				fence = ""
+			}
+			continue
+		} else if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			fence = trimmed[:3]
+			continue
+		}
+		line = codeSpan.ReplaceAllString(line, "")
+
+		for _, re := range []*regexp.Regexp{inlineLink, refLink, autoLink, htmlLink} {
+			for _, m := range re.FindAllStringSubmatch(line, -1) {
+				links = append(links, docLink{File: file, Line: n, URL: m[1]})
+			}
+		}
+	}
+	return links, sc.Err()
+}
+
+// isExternal reports whether the link points outside of the documentation.
+func isExternal(url string) bool {
+	return strings.Contains(url, "://") || strings.HasPrefix(url, "mailto:") || strings.HasPrefix(url, "//")
+}
+
+// splitFragment splits a link into its path and its fragment, dropping
+// the query, if any.
+func splitFragment(url string) (p, frag string) {
+	if i := strings.Index(url, "#"); i >= 0 {
+		url, frag = url[:i], url[i+1:]
+	}
+	if i := strings.Index(url, "?"); i >= 0 {
+		url = url[:i]
+	}
+	return url, frag
+}
+
+// resolveLink returns the path, relative to the root, of the file an
+// internal link points to. Links with no path point to the file itself.
+func resolveLink(l docLink) string {
+	p, _ := splitFragment(l.URL)
+	if p == "" {
+		return l.File
+	}
+	if strings.HasPrefix(p, "/") {
+		return path.Clean(strings.TrimPrefix(p, "/"))
+	}
+	return path.Join(path.Dir(l.File), p)
+}
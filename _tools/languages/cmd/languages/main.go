@@ -0,0 +1,696 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net"
+	"net/smtp"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bblfsh/documentation/_tools/languages"
+	"gopkg.in/bblfsh/sdk.v1/manifest"
+	"gopkg.in/bblfsh/sdk.v1/manifest/discovery"
+)
+
+// version is the version of the tool, set at build time with
+// -ldflags "-X main.version=...".
+var version = "dev"
+
+var (
+	outFormat   = flag.String("o", "md", "output format (md, html, table, json, jsonl streaming a driver per line, or mermaid and dot for a diagram of the drivers)")
+	color       = flag.Bool("color", false, "color the statuses and features of -o table")
+	plainSyms   = flag.String("plain-symbols", "html", "comma-separated formats rendering the ✓ and ✗ marks as text for screen readers (md, html, table), empty for none")
+	community   = flag.String("community", "", "YAML file listing community drivers to add to the table")
+	gitlabGroup = flag.String("gitlab-group", "", "GitLab group to discover additional drivers from")
+	gitlabURL   = flag.String("gitlab-url", "https://gitlab.com", "GitLab instance used with -gitlab-group")
+	gitlabToken = flag.String("gitlab-token", "", "GitLab access token, for private groups (default $GITLAB_TOKEN)")
+	statusFile  = flag.String("statuses", "", "YAML file renaming and ordering the statuses of the drivers, and selecting the supported ones")
+	overrides   = flag.String("overrides", "", "YAML file correcting the details of the discovered drivers, like their names")
+	discoverRef = flag.String("discovery-ref", "", "commit, tag or branch of the driver repositories to read the manifests from, to regenerate the documents as of a release")
+	resume      = flag.String("resume", "", "partial JSON output of an interrupted run, to enrich only the drivers missing in it")
+	partial     = flag.String("partial", "partial.json", "file to write the drivers enriched until an interruption to, to continue with -resume")
+	input       = flag.String("input", "", "JSON output of a previous run to render instead of discovering and enriching the drivers")
+	fallback    = flag.String("fallback", "dockerhub", "where to list the official drivers from if the discovery in GitHub fails: dockerhub, the JSON output of a previous run, or none; such a run exits with 3 and is not compared with -snapshot nor recorded in -history")
+	pagesDir    = flag.String("pages", "", "directory to write a detail page per driver to, along with the table")
+	page        = flag.String("page", "languages", "page to generate (languages or maintainers)")
+	dashboard   = flag.Bool("dashboard", false, "add a maintainer dashboard with the drivers with the largest backlog")
+	helpWanted  = flag.Bool("help-wanted", false, "add the missing pieces of the drivers in development, as tasks linking to their repositories")
+	activeDays  = flag.Int("active-days", 90, "days since the last commit or release of an active driver, for the activity column")
+	staleDays   = flag.Int("stale-days", 365, "days since the last commit or release of a stale driver, for the activity column")
+	issueGrowth = flag.Int("issue-growth", 10, "growth of the open issues in -history that makes an active driver slow, for the activity column (0 to ignore)")
+	wantedRepo  = flag.String("wanted-repo", "bblfsh/bblfshd", "GitHub repository with the issues requesting new languages")
+	wantedLabel = flag.String("wanted-label", "", "label of the issues of -wanted-repo requesting new languages, to add the most requested ones ranked by reactions")
+	compat      = flag.Bool("compat", false, "add a compatibility matrix of drivers and SDK versions")
+	coverage    = flag.Bool("coverage", false, "get the test coverage of the drivers from Codecov or Coveralls")
+	benchmark   = flag.String("benchmark", "", "directory with a corpus of files per language, like go/, to measure the performance of the driver images (needs Docker)")
+	smokeTest   = flag.Bool("smoke-test", false, "pull the driver images and parse a file with them, to show which ones work (needs Docker)")
+	enrichers   = flag.String("enrichers", "", "comma-separated enrichers to run, all the enabled ones by default ("+
+		strings.Join(languages.EnricherNames(), ", ")+")")
+	plugins     = flag.String("plugin", "", "comma-separated programs that receive the JSON of each driver and return extra fields, added as columns")
+	bblfshd     = flag.String("bblfshd", "", "address of a bblfshd, like localhost:9432, to add a column with the versions of the drivers installed in it")
+	scan        = flag.Bool("scan", false, "count the high and critical vulnerabilities of the driver images (needs Trivy)")
+	samples     = flag.String("samples", "", "directory with a sample file per language (see _tools/languages/_samples) to parse with the driver images, for the detail pages")
+	snapshot    = flag.String("snapshot", "", "JSON output of a previous run, to find the changes of the drivers since then")
+	feed        = flag.String("feed", "", "Atom feed to add the changes since -snapshot to")
+	notifyURL   = flag.String("notify-url", "", "Slack-compatible webhook to post the changes since -snapshot to")
+	digestFile  = flag.String("digest-file", "", "file to write an HTML email with the changes since -snapshot to, to send with sendmail -t")
+	digestSMTP  = flag.String("digest-smtp", "", "SMTP server, like smtp.example.com:587, to send the email with the changes since -snapshot through ($SMTP_USERNAME and $SMTP_PASSWORD are used to log in)")
+	digestFrom  = flag.String("digest-from", "bblfsh-drivers@localhost", "sender of the digest email")
+	digestTo    = flag.String("digest-to", "", "comma-separated recipients of the digest email")
+	history     = flag.String("history", "", "file to append a summary of the run to, one JSON line per run")
+	trend       = flag.Bool("trend", false, "add a trend section from the runs in -history")
+	withSummary = flag.Bool("summary", false, "start the document with the number of drivers by status, with images and with UAST support")
+	groupStatus = flag.Bool("group-status", false, "write a table for each status of the official drivers, with their counts")
+	editors     = flag.String("editors", "", "file to write a JSON mapping of file extensions and languages to the driver images to, like editors.json, for editor plugins")
+	index       = flag.String("index", "", "file to write a JSON index of the driver images to, like drivers.index.json, for bblfshctl")
+	apiDir      = flag.String("api", "", "directory to write a static JSON API of the drivers to, with drivers/index.json and a file per driver")
+	noTimestamp = flag.Bool("no-timestamp", false, "omit the generation date from the metadata comment, for reproducible builds")
+	templates   = flag.String("templates", "", "directory with the header, footer and legend of the document, like header.md, replacing the built-in ones")
+	locales     = flag.String("locales", "", "directory of message files, like es.yml, to also write the document translated to each locale, like languages.es.md")
+	mirrors     = flag.String("registry-mirror", "", "comma-separated registry mirrors, like https://mirror.gcr.io, to try in order when Docker Hub fails")
+	proxy       = flag.String("proxy", "", "proxy for all the requests, like http://proxy:3128 or socks5://localhost:1080 (default from $HTTPS_PROXY, $HTTP_PROXY and $NO_PROXY)")
+	reportFile  = flag.String("report", "", "file to write a JSON report of the run to, with the latency of the lookups of each driver and source, cache hits, retries and failures (durations in nanoseconds)")
+	eventsJSON  = flag.Bool("events-json", false, "write the progress of the run to stderr as JSON lines, like lookups of each driver, log lines and problems")
+	verifyURLs  = flag.Bool("verify-links", false, "check that the links of the generated document respond, reporting the dead ones")
+	allowRegs   = flag.Bool("allow-regressions", false, "do not fail the check when a driver lost its image or features, was removed or its status downgraded")
+	strict      = flag.Bool("strict", false, "fail if -verify-links finds dead links")
+	verbose     = flag.Bool("v", false, "log details of the requests, like the GitHub quota left")
+	output      = flag.String("out", "", "file to write the output to instead of the standard output, only rewritten if it changes")
+	watch       = flag.Bool("watch", false, "keep running, writing the output to -out every -interval")
+	interval    = flag.Duration("interval", time.Hour, "time between the runs of -watch")
+	createPR    = flag.Bool("create-pr", false, "open a pull request in -pr-repo with the outputs if they differ from the committed ones, writing the document to -out (needs $GITHUB_TOKEN)")
+	prRepo      = flag.String("pr-repo", "bblfsh/documentation", "repository to open the pull request of -create-pr in")
+	prBase      = flag.String("pr-base", "master", "branch to open the pull request of -create-pr against")
+	prRemote    = flag.String("pr-remote", "origin", "git remote to push the branch of -create-pr to")
+	check       = flag.String("check", "", "check that this file is up to date instead of writing the output (deprecated, use the check command)")
+	annotations = flag.Bool("annotations", false, "report problems as GitHub Actions workflow commands, to show them inline in pull requests")
+	metricsFile = flag.String("metrics-file", "", "file to write Prometheus metrics of the drivers to, for the textfile collector")
+	metricsOut  = flag.String("metrics-out", "", "file to write a timestamped OpenMetrics snapshot of the drivers to, to backfill it into a monitoring system")
+	columns     = flag.String("columns", "", "comma-separated optional columns to add to the table ("+
+		strings.Join(languages.OptionalColumnNames(), ", ")+", or feature:<name> for any manifest feature)")
+)
+
+func main() {
+	flag.Usage = usage
+	flag.Parse()
+	// only the generated documents are written to stdout
+	log.SetOutput(os.Stderr)
+	if *eventsJSON {
+		enableEvents()
+	}
+	var col *collector
+	if *reportFile != "" {
+		col = newCollector()
+		prev := languages.Events
+		languages.Events = func(e languages.Event) {
+			if prev != nil {
+				prev(e)
+			}
+			col.add(e)
+		}
+	}
+	languages.Verbose = *verbose
+	languages.Activity = languages.ActivityThresholds{
+		Active:      time.Duration(*activeDays) * 24 * time.Hour,
+		Slow:        time.Duration(*staleDays) * 24 * time.Hour,
+		IssueGrowth: *issueGrowth,
+	}
+	if *proxy != "" {
+		u, err := url.Parse(*proxy)
+		if err != nil {
+			log.Fatalf("invalid -proxy: %v", err)
+		}
+		languages.Proxy = u
+	}
+	plain := make(map[string]bool)
+	for _, format := range splitList(*plainSyms) {
+		if _, ok := languages.Renderers[format]; !ok {
+			log.Fatalf("invalid -plain-symbols: unknown format %q", format)
+		}
+		plain[format] = true
+	}
+	for format := range languages.Renderers {
+		if err := languages.SetPlainSymbols(format, plain[format]); err != nil {
+			log.Fatalf("invalid -plain-symbols: %v", err)
+		}
+	}
+	languages.InstallTransport()
+
+	var args []string
+	if flag.NArg() > 1 {
+		args = flag.Args()[1:]
+	}
+	cmd, err := lookupCommand(flag.Arg(0))
+	if err == nil {
+		// the one-shot runs stop gracefully on signals, while serve and
+		// -watch keep running, with the default handling
+		if cmd.name != "serve" && !*watch {
+			handleSignals()
+		}
+		err = cmd.run(args)
+	}
+	if err == nil && reported.count[levelError] != 0 {
+		err = fmt.Errorf("%d problems found", reported.count[levelError])
+	}
+	if col != nil {
+		if werr := col.write(*reportFile); werr != nil {
+			log.Print(werr)
+		}
+	}
+	code := exitCode(err)
+	if *eventsJSON {
+		printSummary(logEvents{})
+	} else {
+		printSummary(os.Stderr)
+	}
+	if err != nil {
+		log.Print(err)
+	}
+	os.Exit(code)
+}
+
+// errRegression is returned by the check modes when a driver regressed
+// since the checked document, without -allow-regressions.
+var errRegression = errors.New("drivers regressed since the checked document, pass -allow-regressions to accept it")
+
+// runCheck generates the document and reports it as stale if it differs
+// from the one at path. The drivers that regressed since the document are
+// reported too, and fail the check unless -allow-regressions is set.
+func runCheck(path string) error {
+	old, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	list, err := run(&buf)
+	if err != nil {
+		return err
+	}
+	if reason := degradedReason(); reason != "" {
+		// the drivers listed from the fallback lack most details, so they
+		// would all look like regressions
+		return fmt.Errorf("cannot check %s against a degraded discovery: %s", path, reason)
+	}
+	prev := languages.ReadDocumentDrivers(old)
+	statuses.Unlabel(prev)
+	if regs := languages.Regressions(prev, list); len(regs) != 0 {
+		report := reportError
+		if *allowRegs {
+			report = reportWarning
+		}
+		for _, c := range regs {
+			report(languages.Problem{File: path, Msg: "regression: " + c.Summary})
+		}
+		if !*allowRegs {
+			return errRegression
+		}
+	}
+	// the metadata changes on every run, but does not make the file stale
+	old, out := languages.StripMetadata(old), languages.StripMetadata(buf.Bytes())
+	if !bytes.Equal(old, out) {
+		reportError(languages.Problem{
+			File: path,
+			Line: firstDiffLine(old, out),
+			Msg:  "not up to date, run 'make languages'",
+		})
+		return errStale
+	}
+	return nil
+}
+
+// run loads the drivers and writes the document selected with the flags,
+// returning the drivers.
+func run(w io.Writer) ([]languages.Driver, error) {
+	cols, err := validateFlags()
+	if err != nil {
+		return nil, err
+	}
+	// avatars are only rendered in HTML, but the names are always used by
+	// the maintainers page
+	var stream func(languages.Driver)
+	if *outFormat == "jsonl" {
+		stream = jsonLines(w)
+	}
+	list, err := loadDrivers(*outFormat == "html" || *page == "maintainers", stream)
+	if err != nil {
+		return nil, err
+	}
+	cols = append(cols, languages.ExtraColumns(list)...)
+
+	// the outputs independent of each other, written in parallel once the
+	// drivers are loaded
+	var artifacts []func() error
+	if *metricsFile != "" {
+		artifacts = append(artifacts, func() error { return languages.WriteMetricsFile(*metricsFile, list) })
+	}
+	if *metricsOut != "" {
+		artifacts = append(artifacts, func() error { return languages.WriteOpenMetricsFile(*metricsOut, list) })
+	}
+
+	if *snapshot != "" && degradedReason() != "" {
+		// the drivers missing in the fallback are not removed ones
+		log.Println("the discovery is degraded, not comparing it with", *snapshot)
+	} else if *snapshot != "" {
+		old, err := languages.ReadDrivers(*snapshot)
+		if err != nil {
+			return nil, err
+		}
+		changes := languages.DiffDrivers(old, list)
+		log.Println(len(changes), "driver changes since", *snapshot)
+		if *feed != "" {
+			if err := languages.UpdateFeed(*feed, changes, time.Now()); err != nil {
+				return nil, err
+			}
+		}
+		if *notifyURL != "" {
+			if err := languages.Notify(*notifyURL, changes); err != nil {
+				log.Println(err)
+			}
+		}
+		if err := sendDigest(changes); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, d := range list {
+		if d.Status.Rank() >= manifest.Beta.Rank() && d.DockerhubURL == "" {
+			p := languages.Problem{Msg: fmt.Sprintf("%s driver is %s but has no container image", d.Language, d.Status)}
+			if d.Community {
+				p.File = *community
+			}
+			reportWarning(p)
+		}
+		if d.ImageWorks != nil && !*d.ImageWorks {
+			reportWarning(languages.Problem{Msg: fmt.Sprintf("%s driver image %s does not work", d.Language, d.Image)})
+		}
+	}
+
+	var hist []languages.HistoryEntry
+	if *history != "" {
+		// the runs that only check or render the output, or that are
+		// degraded, are not recorded
+		if *check == "" && *input == "" && degradedReason() == "" {
+			if err := languages.AppendHistory(*history, languages.NewHistoryEntry(time.Now(), list)); err != nil {
+				return nil, err
+			}
+		}
+		if hist, err = languages.ReadHistory(*history); err != nil {
+			return nil, err
+		}
+		languages.SetIssueGrowth(list, hist)
+	}
+
+	if *index != "" && *check == "" {
+		artifacts = append(artifacts, func() error { return writeIndex(*index, list, languages.WriteIndex) })
+	}
+	if *editors != "" && *check == "" {
+		artifacts = append(artifacts, func() error { return writeIndex(*editors, list, languages.WriteEditors) })
+	}
+	if *apiDir != "" && *check == "" {
+		artifacts = append(artifacts, func() error { return languages.WriteAPI(*apiDir, list) })
+	}
+
+	switch *outFormat {
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "\t")
+		artifacts = append(artifacts, func() error { return enc.Encode(list) })
+		return list, languages.RunParallel(0, artifacts...)
+	case "jsonl":
+		// already streamed while loading them
+		return list, languages.RunParallel(0, artifacts...)
+	case "mermaid":
+		languages.WriteMermaid(w, list)
+		return list, languages.RunParallel(0, artifacts...)
+	case "dot":
+		languages.WriteDOT(w, list)
+		return list, languages.RunParallel(0, artifacts...)
+	}
+	f, ok := languages.Renderers[*outFormat]
+	if !ok {
+		*outFormat = "md"
+		f = languages.Renderers[*outFormat]
+	}
+	if t, ok := f.(languages.Terminal); ok && *color {
+		t.Color = true
+		f = t
+	}
+	if *templates != "" {
+		t, err := languages.ReadTemplates(*templates, *outFormat)
+		if err != nil {
+			return nil, err
+		}
+		f = languages.WithTemplates(f, t)
+	}
+
+	if *pagesDir != "" && *check == "" {
+		artifacts = append(artifacts, func() error { return languages.WritePages(*pagesDir, *outFormat, f, list) })
+	}
+
+	opts := documentOptions(cols, hist)
+	if *wantedLabel != "" {
+		if opts.Wanted, err = languages.LoadWanted(*wantedRepo, *wantedLabel); err != nil {
+			return nil, err
+		}
+	}
+	if *locales != "" && *check == "" {
+		tasks, err := localeTasks(*locales, f, list, opts)
+		if err != nil {
+			return nil, err
+		}
+		artifacts = append(artifacts, tasks...)
+	}
+	artifacts = append(artifacts, func() error {
+		if !*verifyURLs {
+			languages.WriteDocument(w, f, list, opts)
+			return nil
+		}
+		var buf bytes.Buffer
+		languages.WriteDocument(io.MultiWriter(w, &buf), f, list, opts)
+		verifyLinks(*page+"."+*outFormat, buf.Bytes())
+		return nil
+	})
+	return list, languages.RunParallel(0, artifacts...)
+}
+
+// jsonLines returns a function that writes each driver to w as a line of
+// JSON, safe to call from several goroutines.
+func jsonLines(w io.Writer) func(languages.Driver) {
+	var mu sync.Mutex
+	enc := json.NewEncoder(w)
+	return func(d languages.Driver) {
+		mu.Lock()
+		defer mu.Unlock()
+		if err := enc.Encode(d); err != nil {
+			log.Println(err)
+		}
+	}
+}
+
+// localeTasks returns the tasks writing the document translated to each
+// locale found in dir, next to the main one, like languages.es.md.
+func localeTasks(dir string, f languages.Renderer, list []languages.Driver, opts *languages.DocumentOptions) ([]func() error, error) {
+	locs, err := languages.ReadLocales(dir)
+	if err != nil {
+		return nil, err
+	}
+	var tasks []func() error
+	for loc, msgs := range locs {
+		loc, msgs := loc, msgs
+		tasks = append(tasks, func() error {
+			var buf bytes.Buffer
+			languages.WriteDocument(&buf, languages.Localize(f, msgs), list, opts)
+			path := fmt.Sprintf("%s.%s.%s", *page, loc, *outFormat)
+			return ioutil.WriteFile(path, buf.Bytes(), 0644)
+		})
+	}
+	return tasks, nil
+}
+
+// sendDigest writes the digest email of the changes to -digest-file, and
+// sends it through -digest-smtp.
+func sendDigest(changes []languages.Change) error {
+	d := languages.Digest{
+		From:    *digestFrom,
+		To:      splitList(*digestTo),
+		Date:    time.Now(),
+		Changes: changes,
+	}
+	if *digestFile != "" {
+		var buf bytes.Buffer
+		if err := languages.WriteDigest(&buf, d); err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(*digestFile, buf.Bytes(), 0644); err != nil {
+			return err
+		}
+	}
+	if *digestSMTP == "" {
+		return nil
+	}
+	var auth smtp.Auth
+	if user := os.Getenv("SMTP_USERNAME"); user != "" {
+		host, _, err := net.SplitHostPort(*digestSMTP)
+		if err != nil {
+			return err
+		}
+		auth = smtp.PlainAuth("", user, os.Getenv("SMTP_PASSWORD"), host)
+	}
+	return languages.SendDigest(*digestSMTP, auth, d)
+}
+
+// writeIndex writes an index of the drivers to path, like the one of the
+// driver images.
+func writeIndex(path string, list []languages.Driver, write func(io.Writer, []languages.Driver) error) error {
+	var buf bytes.Buffer
+	if err := write(&buf, list); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, buf.Bytes(), 0644)
+}
+
+// validateFlags checks the flags of the main command, and returns the
+// columns of the table.
+func validateFlags() ([]languages.Column, error) {
+	names := *columns
+	if *bblfshd != "" && !contains(splitList(names), "installed") {
+		names = strings.Join(append(splitList(names), "installed"), ",")
+	}
+	cols, err := languages.SelectColumns(names)
+	if err != nil {
+		return nil, err
+	}
+	if *page != "languages" && *page != "maintainers" {
+		return nil, fmt.Errorf("unknown page: %q", *page)
+	}
+	if (*feed != "" || *notifyURL != "") && *snapshot == "" {
+		return nil, fmt.Errorf("-feed and -notify-url require -snapshot")
+	}
+	if (*digestFile != "" || *digestSMTP != "") && *snapshot == "" {
+		return nil, fmt.Errorf("-digest-file and -digest-smtp require -snapshot")
+	}
+	if *digestSMTP != "" && *digestTo == "" {
+		return nil, fmt.Errorf("-digest-smtp requires -digest-to")
+	}
+	if *trend && *history == "" {
+		return nil, fmt.Errorf("-trend requires -history")
+	}
+	if *statusFile != "" {
+		if statuses, err = languages.ReadStatusConfig(*statusFile); err != nil {
+			return nil, err
+		}
+	}
+	return cols, nil
+}
+
+// statuses are the labels of the statuses read from -statuses.
+var statuses languages.StatusConfig
+
+// loadDrivers discovers and enriches the drivers selected with the flags,
+// or reads them from -input.
+// The profiles of the maintainers are only loaded if withProfiles is set.
+// done, if set, is called with each driver once it is loaded.
+func loadDrivers(withProfiles bool, done func(languages.Driver)) ([]languages.Driver, error) {
+	if *input != "" {
+		// the details were already loaded by the run that wrote the input
+		list, err := languages.ReadDrivers(*input)
+		// the input may come from a degraded run
+		setDegraded("")
+		for _, d := range list {
+			if d.Degraded != "" {
+				setDegraded(d.Degraded)
+			}
+		}
+		if err == nil && done != nil {
+			for _, d := range list {
+				done(d)
+			}
+		}
+		return list, err
+	}
+	list, err := languages.Discover(runCtx, discoverOptions())
+	if err != nil {
+		return nil, err
+	}
+	e := newEnrichment()
+	todo := list
+	if *resume != "" {
+		if todo, err = e.resume(*resume, list); err != nil {
+			return nil, err
+		}
+	}
+	// the installed versions are only known once all the drivers are
+	// enriched, so they are not streamed with -bblfshd
+	stream := done != nil && *bblfshd == ""
+	if stream {
+		for _, d := range list {
+			if resumed, ok := e.done[d.Language]; ok {
+				done(resumed)
+			}
+		}
+	}
+	opts := &languages.EnrichOptions{
+		Profiles:  withProfiles,
+		Coverage:  *coverage,
+		Samples:   *samples,
+		Benchmark: *benchmark,
+		Scan:      *scan,
+		SmokeTest: *smokeTest,
+	}
+	if *mirrors != "" {
+		opts.Registry = languages.NewDockerHub(splitList(*mirrors)...)
+	}
+	for _, path := range splitList(*plugins) {
+		opts.Extra = append(opts.Extra, languages.PluginEnricher{Path: path})
+	}
+	if *enrichers != "" {
+		opts.Enrichers = splitList(*enrichers)
+		for _, name := range opts.Enrichers {
+			if f, ok := enricherFlags[name]; ok && !f.enabled(opts) {
+				return nil, fmt.Errorf("enricher %s needs %s", name, f.name)
+			}
+		}
+	}
+	opts.Done = func(d languages.Driver) {
+		e.add(d)
+		if stream {
+			done(d)
+		}
+	}
+	if err := languages.Enrich(runCtx, todo, opts); err != nil {
+		if runCtx.Err() != nil {
+			return nil, e.interrupted(*partial, list)
+		}
+		return nil, err
+	}
+	list = e.merge(list)
+	if *bblfshd != "" {
+		if err := languages.LoadInstalled(*bblfshd, list); err != nil {
+			return nil, err
+		}
+		if done != nil {
+			for _, d := range list {
+				done(d)
+			}
+		}
+	}
+	return list, nil
+}
+
+// enricherFlags are the flags enabling the enrichers that do not always
+// run, by name.
+var enricherFlags = map[string]struct {
+	name    string
+	enabled func(*languages.EnrichOptions) bool
+}{
+	"smoke-test": {"-smoke-test", func(o *languages.EnrichOptions) bool { return o.SmokeTest }},
+	"scan":       {"-scan", func(o *languages.EnrichOptions) bool { return o.Scan }},
+	"profiles":   {"-o html or -page maintainers", func(o *languages.EnrichOptions) bool { return o.Profiles }},
+	"coverage":   {"-coverage", func(o *languages.EnrichOptions) bool { return o.Coverage }},
+	"examples":   {"-samples", func(o *languages.EnrichOptions) bool { return o.Samples != "" }},
+}
+
+// degraded is the reason the official drivers were listed from the
+// fallback, if they were. It is set by the refreshes of serve while the
+// documents are served.
+var degraded = struct {
+	sync.Mutex
+	reason string
+}{}
+
+func setDegraded(reason string) {
+	degraded.Lock()
+	degraded.reason = reason
+	degraded.Unlock()
+}
+
+// degradedReason returns the reason the last discovery was degraded, or
+// an empty string if it was not.
+func degradedReason() string {
+	degraded.Lock()
+	defer degraded.Unlock()
+	return degraded.reason
+}
+
+// discoverOptions returns the options of the discovery selected with the
+// flags.
+func discoverOptions() *languages.DiscoverOptions {
+	token := *gitlabToken
+	if token == "" {
+		token = os.Getenv("GITLAB_TOKEN")
+	}
+	// a previous run of -watch may have been degraded
+	setDegraded("")
+	opts := &languages.DiscoverOptions{
+		Community:   *community,
+		Overrides:   *overrides,
+		Ref:         *discoverRef,
+		GitlabGroup: *gitlabGroup,
+		GitlabURL:   *gitlabURL,
+		GitlabToken: token,
+		Report:      reportError,
+		Degraded: func(reason string) {
+			setDegraded(reason)
+			reportWarning(languages.Problem{Msg: reason})
+		},
+	}
+	switch *fallback {
+	case "none":
+	case "dockerhub":
+		opts.Fallback = languages.RegistrySource{}
+	default:
+		opts.Fallback = languages.CacheSource{Path: *fallback}
+	}
+	return opts
+}
+
+// documentOptions returns the options of the document selected with the
+// flags.
+func documentOptions(cols []languages.Column, hist []languages.HistoryEntry) *languages.DocumentOptions {
+	opts := &languages.DocumentOptions{
+		Page:          *page,
+		Columns:       cols,
+		Compat:        *compat,
+		Dashboard:     *dashboard,
+		GroupByStatus: *groupStatus,
+		Summary:       *withSummary,
+		HelpWanted:    *helpWanted,
+		Degraded:      degradedReason(),
+		Statuses:      statuses,
+	}
+	if *trend {
+		opts.History = hist
+	}
+	meta := &languages.Metadata{Version: version, Sources: dataSources(), Ref: *discoverRef}
+	if !*noTimestamp {
+		meta.Date = time.Now()
+	}
+	opts.Metadata = meta
+	return opts
+}
+
+// dataSources describes where the drivers are discovered from, for the
+// metadata of the document.
+func dataSources() []string {
+	if *input != "" {
+		return []string{*input}
+	}
+	srcs := []string{"https://github.com/" + discovery.GithubOrg}
+	if *gitlabGroup != "" {
+		srcs = append(srcs, strings.TrimSuffix(*gitlabURL, "/")+"/"+*gitlabGroup)
+	}
+	if *community != "" {
+		srcs = append(srcs, *community)
+	}
+	return srcs
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/bblfsh/documentation/_tools/languages"
+)
+
+// runOrgs implements the orgs subcommand, that compares the drivers of two
+// GitHub organizations, like upstream and a fork of its drivers.
+func runOrgs(args []string) error {
+	fs := flag.NewFlagSet("orgs", flag.ExitOnError)
+	out := fs.String("o", "md", "output format (md or html)")
+	fs.Parse(args)
+	if fs.NArg() != 2 {
+		return fmt.Errorf("usage: orgs bblfsh fork-org")
+	}
+	f, ok := languages.Renderers[*out]
+	if !ok {
+		return fmt.Errorf("unknown output format: %q", *out)
+	}
+
+	var lists [2][]languages.Driver
+	for i, org := range fs.Args() {
+		list, err := orgDrivers(org)
+		if err != nil {
+			return fmt.Errorf("%s: %v", org, err)
+		}
+		lists[i] = list
+	}
+	languages.WriteOrgComparison(os.Stdout, f, fs.Arg(0), lists[0], fs.Arg(1), lists[1])
+	return nil
+}
+
+// orgDrivers discovers the drivers of the organization, with the versions
+// of their images and releases.
+func orgDrivers(org string) ([]languages.Driver, error) {
+	ctx := runCtx
+	list, err := languages.Discover(ctx, &languages.DiscoverOptions{
+		Sources: []languages.DriverSource{languages.OfficialSource{Organization: org}},
+		Report:  reportError,
+	})
+	if err != nil {
+		return nil, err
+	}
+	opts := &languages.EnrichOptions{Enrichers: []string{"docker", "github"}}
+	if *mirrors != "" {
+		opts.Registry = languages.NewDockerHub(splitList(*mirrors)...)
+	}
+	return list, languages.Enrich(ctx, list, opts)
+}
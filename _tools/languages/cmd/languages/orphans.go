@@ -0,0 +1,91 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/bblfsh/documentation/_tools/languages"
+)
+
+// entryPages are the pages readers start navigating the book from.
+var entryPages = []string{"README.md", "SUMMARY.md"}
+
+// runOrphans implements the orphans subcommand, that reports the markdown
+// files that cannot be reached following internal links from the entry
+// pages of the book.
+func runOrphans(args []string) error {
+	fs := flag.NewFlagSet("orphans", flag.ExitOnError)
+	root := fs.String("root", ".", "root directory of the documentation")
+	exclude := fs.String("exclude", defaultExclude, "comma-separated globs of files that are expected to be orphans")
+	fs.Parse(args)
+
+	files, err := walkDocs(*root, splitList(*exclude))
+	if err != nil {
+		return err
+	}
+	graph, err := linkGraph(*root, files)
+	if err != nil {
+		return err
+	}
+
+	reached := reachable(graph, entryPages)
+	var orphans []string
+	for _, f := range files {
+		if !reached[f] {
+			orphans = append(orphans, f)
+		}
+	}
+	for _, f := range orphans {
+		reportError(languages.Problem{File: f, Msg: "not linked from " + strings.Join(entryPages, " or ")})
+	}
+	if len(orphans) != 0 {
+		return fmt.Errorf("%d orphan pages found", len(orphans))
+	}
+	return nil
+}
+
+// linkGraph returns the markdown files each file links to.
+func linkGraph(root string, files []string) (map[string][]string, error) {
+	graph := make(map[string][]string, len(files))
+	for _, f := range files {
+		links, err := extractLinks(root, f)
+		if err != nil {
+			return nil, err
+		}
+		for _, l := range links {
+			if isExternal(l.URL) {
+				continue
+			}
+			target := resolveLink(l)
+			switch path.Ext(target) {
+			case ".html":
+				target = strings.TrimSuffix(target, ".html") + ".md"
+			case "":
+				// links to directories open their README
+				target = path.Join(target, "README.md")
+			}
+			if target != f {
+				graph[f] = append(graph[f], target)
+			}
+		}
+	}
+	return graph, nil
+}
+
+// reachable returns the files that can be reached from the given ones.
+func reachable(graph map[string][]string, from []string) map[string]bool {
+	seen := make(map[string]bool)
+	queue := append([]string{}, from...)
+	for len(queue) != 0 {
+		f := queue[0]
+		queue = queue[1:]
+		if seen[f] {
+			continue
+		}
+		seen[f] = true
+		queue = append(queue, graph[f]...)
+	}
+	return seen
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bblfsh/documentation/_tools/languages"
+)
+
+// Levels of the problems.
+const (
+	levelError   = "error"
+	levelWarning = "warning"
+)
+
+// reported counts the problems reported by level, and keeps the errors for
+// the summary of the run.
+var reported = struct {
+	sync.Mutex
+	count  map[string]int
+	errors []languages.Problem
+}{count: make(map[string]int)}
+
+func reportError(p languages.Problem)   { report(levelError, p) }
+func reportWarning(p languages.Problem) { report(levelWarning, p) }
+
+// report prints a problem to stderr, as a workflow command when running
+// with -annotations, or as an event with -events-json. The runner reads
+// the commands from both stdout and stderr, so they do not mix with the
+// generated documents.
+func report(level string, p languages.Problem) {
+	reported.Lock()
+	defer reported.Unlock()
+	reported.count[level]++
+	if level == levelError {
+		reported.errors = append(reported.errors, p)
+	}
+
+	if events.enc != nil {
+		writeEvent(languages.Event{Time: time.Now().UTC(), Kind: level, Msg: p.Error()})
+		return
+	}
+	if !*annotations {
+		if level == levelWarning {
+			fmt.Fprintln(os.Stderr, "warning: "+p.Error())
+		} else {
+			fmt.Fprintln(os.Stderr, p.Error())
+		}
+		return
+	}
+	var props []string
+	if p.File != "" {
+		props = append(props, "file="+propertyEscaper.Replace(p.File))
+	}
+	if p.Line != 0 {
+		props = append(props, "line="+strconv.Itoa(p.Line))
+	}
+	cmd := level
+	if len(props) != 0 {
+		cmd += " " + strings.Join(props, ",")
+	}
+	fmt.Fprintf(os.Stderr, "::%s::%s\n", cmd, dataEscaper.Replace(p.Msg))
+}
+
+// Escapers of the message and the property values of workflow commands.
+var (
+	dataEscaper     = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A")
+	propertyEscaper = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C")
+)
+
+// firstDiffLine returns the first line, starting at 1, where two versions
+// of a file differ.
+func firstDiffLine(a, b []byte) int {
+	la, lb := bytes.Split(a, []byte("\n")), bytes.Split(b, []byte("\n"))
+	for i := range la {
+		if i >= len(lb) || !bytes.Equal(la[i], lb[i]) {
+			return i + 1
+		}
+	}
+	return len(la) + 1
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"sync"
+	"time"
+
+	"github.com/bblfsh/documentation/_tools/languages"
+)
+
+// runReport is the performance and reliability report of a run, written
+// with -report.
+type runReport struct {
+	Started  time.Time     `json:"started"`
+	Duration time.Duration `json:"duration"`
+	// Drivers are the latencies of the lookups of each driver, by language.
+	Drivers map[string]*driverReport `json:"drivers"`
+	// Sources are the totals of the lookups of each enricher, by name.
+	Sources map[string]*sourceReport `json:"sources"`
+	// Cache are the hits and misses of each cache, by source.
+	Cache map[string]*cacheReport `json:"cache"`
+	// Retries are the requests retried against another service, like a
+	// registry mirror, by source.
+	Retries map[string]int `json:"retries"`
+	// Waits are the time waited for rate limits, by source.
+	Waits    map[string]time.Duration  `json:"waits"`
+	Failures []languages.EnrichFailure `json:"failures"`
+}
+
+type driverReport struct {
+	Total time.Duration `json:"total"`
+	// Latency is the time of the lookup of each source.
+	Latency map[string]time.Duration `json:"latency"`
+}
+
+type sourceReport struct {
+	Lookups int           `json:"lookups"`
+	Total   time.Duration `json:"total"`
+	Max     time.Duration `json:"max"`
+	// Failures are the failures to load the details of the source.
+	Failures int `json:"failures"`
+}
+
+type cacheReport struct {
+	Hits    int     `json:"hits"`
+	Misses  int     `json:"misses"`
+	HitRate float64 `json:"hit_rate"`
+}
+
+// collector builds the report of the run from its events.
+type collector struct {
+	mu sync.Mutex
+	r  runReport
+}
+
+func newCollector() *collector {
+	return &collector{r: runReport{
+		Started: time.Now().UTC(),
+		Drivers: make(map[string]*driverReport),
+		Sources: make(map[string]*sourceReport),
+		Cache:   make(map[string]*cacheReport),
+		Retries: make(map[string]int),
+		Waits:   make(map[string]time.Duration),
+	}}
+}
+
+func (c *collector) add(e languages.Event) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	switch e.Kind {
+	case languages.EventLookupDone:
+		d := c.r.Drivers[e.Driver]
+		if d == nil {
+			d = &driverReport{Latency: make(map[string]time.Duration)}
+			c.r.Drivers[e.Driver] = d
+		}
+		d.Total += e.Duration
+		d.Latency[e.Source] += e.Duration
+		s := c.source(e.Source)
+		s.Lookups++
+		s.Total += e.Duration
+		if e.Duration > s.Max {
+			s.Max = e.Duration
+		}
+	case languages.EventCacheHit, languages.EventCacheMiss:
+		ch := c.r.Cache[e.Source]
+		if ch == nil {
+			ch = &cacheReport{}
+			c.r.Cache[e.Source] = ch
+		}
+		if e.Kind == languages.EventCacheHit {
+			ch.Hits++
+		} else {
+			ch.Misses++
+		}
+		ch.HitRate = float64(ch.Hits) / float64(ch.Hits+ch.Misses)
+	case languages.EventRetry:
+		c.r.Retries[e.Source]++
+	case languages.EventWait:
+		c.r.Waits[e.Source] += e.Duration
+	case languages.EventError:
+		c.source(e.Source).Failures++
+	}
+}
+
+func (c *collector) source(name string) *sourceReport {
+	s := c.r.Sources[name]
+	if s == nil {
+		s = &sourceReport{}
+		c.r.Sources[name] = s
+	}
+	return s
+}
+
+// write writes the report to path, as JSON.
+func (c *collector) write(path string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.r.Duration = time.Since(c.r.Started)
+	c.r.Failures = languages.EnrichFailures()
+	if c.r.Failures == nil {
+		c.r.Failures = []languages.EnrichFailure{}
+	}
+	data, err := json.MarshalIndent(c.r, "", "\t")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, append(data, '\n'), 0644)
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+
+	"github.com/bblfsh/documentation/_tools/languages"
+)
+
+// runCtx is cancelled on SIGINT or SIGTERM, to stop the run gracefully.
+var runCtx, cancelRun = context.WithCancel(context.Background())
+
+// handleSignals stops the run gracefully on the first SIGINT or SIGTERM:
+// no more lookups are started, the ones in flight are cancelled and the
+// drivers enriched until then are written to -partial. A second signal
+// kills the tool. It is only meant for one-shot runs, since the lookups
+// fail for the rest of the process once it is stopped.
+func handleSignals() {
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		s := <-sig
+		signal.Stop(sig)
+		log.Printf("%s: stopping, send it again to kill the tool", s)
+		cancelRun()
+		languages.Shutdown()
+	}()
+}
+
+// enrichment tracks the drivers whose enrichment finished, including the
+// ones resumed from a partial snapshot.
+type enrichment struct {
+	mu   sync.Mutex
+	done map[string]languages.Driver
+}
+
+func newEnrichment() *enrichment {
+	return &enrichment{done: make(map[string]languages.Driver)}
+}
+
+func (e *enrichment) add(d languages.Driver) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.done[d.Language] = d
+}
+
+// resume reads the drivers enriched in the partial snapshot at path, and
+// returns the drivers of the list that are left to enrich.
+func (e *enrichment) resume(path string, list []languages.Driver) ([]languages.Driver, error) {
+	prev, err := languages.ReadDrivers(path)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range prev {
+		e.add(d)
+	}
+	var rest []languages.Driver
+	for _, d := range list {
+		if _, ok := e.done[d.Language]; !ok {
+			rest = append(rest, d)
+		}
+	}
+	log.Printf("resuming %s: %d drivers already enriched, %d left", path, len(list)-len(rest), len(rest))
+	return rest, nil
+}
+
+// merge returns the list with the enriched version of each driver.
+func (e *enrichment) merge(list []languages.Driver) []languages.Driver {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	out := make([]languages.Driver, len(list))
+	for i, d := range list {
+		if done, ok := e.done[d.Language]; ok {
+			d = done
+		}
+		out[i] = d
+	}
+	return out
+}
+
+// interrupted writes the drivers of the list enriched until the run was
+// interrupted to path, and returns the error of the run.
+func (e *enrichment) interrupted(path string, list []languages.Driver) error {
+	e.mu.Lock()
+	var partial []languages.Driver
+	for _, d := range list {
+		if done, ok := e.done[d.Language]; ok {
+			partial = append(partial, done)
+		}
+	}
+	e.mu.Unlock()
+
+	data, err := json.MarshalIndent(partial, "", "\t")
+	if err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return err
+	}
+	return fmt.Errorf("interrupted with %d of %d drivers enriched, written to %s: continue with -resume %s",
+		len(partial), len(list), path, path)
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"io/ioutil"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/bblfsh/documentation/_tools/languages"
+)
+
+// searchDoc is a document of the search index. The index is the list of
+// documents, to be added to lunr or elasticlunr with "id" as the ref and
+// "title", "body" and "keywords" as the fields.
+type searchDoc struct {
+	// ID is the URL of the page in the book, relative to its root.
+	ID       string   `json:"id"`
+	Title    string   `json:"title"`
+	Body     string   `json:"body"`
+	Keywords []string `json:"keywords,omitempty"`
+}
+
+// runSearch implements the search subcommand, that writes the search index
+// of the documentation. The pages of the drivers written with -pages are
+// indexed with the names, keys and aliases of their languages as keywords.
+func runSearch(args []string) error {
+	fs := flag.NewFlagSet("search", flag.ExitOnError)
+	root := fs.String("root", ".", "root directory of the documentation")
+	exclude := fs.String("exclude", defaultExclude, "comma-separated globs of files not to index")
+	out := fs.String("out", "search_index.json", "file to write the index to")
+	fs.Parse(args)
+
+	files, err := walkDocs(*root, splitList(*exclude))
+	if err != nil {
+		return err
+	}
+	keywords, err := driverKeywords()
+	if err != nil {
+		return err
+	}
+
+	docs := make([]searchDoc, 0, len(files))
+	for _, f := range files {
+		if path.Base(f) == "SUMMARY.md" {
+			continue
+		}
+		data, err := ioutil.ReadFile(filepath.Join(*root, filepath.FromSlash(f)))
+		if err != nil {
+			return err
+		}
+		title, err := docTitle(filepath.Join(*root, filepath.FromSlash(f)))
+		if err != nil {
+			return err
+		}
+		if title == "" {
+			title = strings.TrimSuffix(path.Base(f), ".md")
+		}
+		doc := searchDoc{ID: pageURL(f), Title: title, Body: plainText(data)}
+		if dir, name := path.Split(f); dir == *pagesDir+"/" {
+			doc.Keywords = keywords[strings.TrimSuffix(name, ".md")]
+		}
+		docs = append(docs, doc)
+	}
+
+	data, err := json.Marshal(docs)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(*out, append(data, '\n'), 0644)
+}
+
+// driverKeywords returns the keywords of the drivers, by language key: the
+// key, name and aliases of the language. The drivers are read from -input,
+// or discovered without enriching them.
+func driverKeywords() (map[string][]string, error) {
+	var (
+		list []languages.Driver
+		err  error
+	)
+	if *input != "" {
+		list, err = languages.ReadDrivers(*input)
+	} else {
+		list, err = languages.Discover(runCtx, discoverOptions())
+	}
+	if err != nil {
+		return nil, err
+	}
+	m := make(map[string][]string, len(list))
+	for _, d := range list {
+		kw := []string{d.Language}
+		if d.Name != "" && !strings.EqualFold(d.Name, d.Language) {
+			kw = append(kw, d.Name)
+		}
+		m[d.Language] = append(kw, d.Aliases...)
+	}
+	return m, nil
+}
+
+// pageURL returns the URL of a markdown file in the book, where README.md
+// files are the index of their directory.
+func pageURL(f string) string {
+	if path.Base(f) == "README.md" {
+		return path.Join(path.Dir(f), "index.html")
+	}
+	return strings.TrimSuffix(f, ".md") + ".html"
+}
+
+var (
+	mdCodeFence = regexp.MustCompile("(?m)^```.*$")
+	mdImage     = regexp.MustCompile(`!\[([^\]]*)\]\([^)]*\)`)
+	mdLink      = regexp.MustCompile(`\[([^\]]*)\]\([^)]*\)`)
+	mdHTML      = regexp.MustCompile(`<[^>]+>`)
+	mdMarks     = strings.NewReplacer("#", "", "*", "", "`", "", "|", " ", "\\", "")
+	mdSpaces    = regexp.MustCompile(`\s+`)
+)
+
+// plainText reduces markdown to its text, for the body of the index.
+func plainText(data []byte) string {
+	data = mdCodeFence.ReplaceAll(data, nil)
+	data = mdImage.ReplaceAll(data, []byte("$1"))
+	data = mdLink.ReplaceAll(data, []byte("$1"))
+	data = mdHTML.ReplaceAll(data, nil)
+	s := mdMarks.Replace(string(bytes.TrimSpace(data)))
+	return strings.TrimSpace(mdSpaces.ReplaceAllString(s, " "))
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/bblfsh/documentation/_tools/languages"
+)
+
+// runServe implements the serve subcommand, that serves the table of
+// drivers over HTTP and refreshes it periodically. The flags of the main
+// command select the drivers and the content of the table.
+func runServe(args []string) error {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	addr := fs.String("addr", ":8080", "address to listen on")
+	interval := fs.Duration("interval", time.Hour, "interval between refreshes of the driver data")
+	fs.Parse(args)
+
+	cols, err := validateFlags()
+	if err != nil {
+		return err
+	}
+	s := &server{cols: cols}
+	if err := s.refresh(); err != nil {
+		return err
+	}
+	go func() {
+		for range time.Tick(*interval) {
+			if err := s.refresh(); err != nil {
+				log.Printf("cannot refresh the drivers, keeping the previous data: %v", err)
+			}
+		}
+	}()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/languages.json", s.serveJSON)
+	mux.HandleFunc("/languages.html", s.serveFormat("html", "text/html; charset=utf-8"))
+	mux.HandleFunc("/languages.md", s.serveFormat("md", "text/markdown; charset=utf-8"))
+	mux.HandleFunc("/metrics", s.serveMetrics)
+	log.Println("listening on", *addr)
+	return http.ListenAndServe(*addr, mux)
+}
+
+// server keeps the last data of the drivers in memory.
+type server struct {
+	cols []languages.Column
+
+	mu   sync.RWMutex
+	list []languages.Driver
+	hist []languages.HistoryEntry
+	// degraded is the reason the served drivers may be incomplete, since
+	// the global one changes with the next refresh.
+	degraded string
+	updated  time.Time
+}
+
+// refresh loads the drivers again, and replaces the served ones if it
+// succeeds.
+func (s *server) refresh() error {
+	list, err := loadDrivers(true, nil)
+	if err != nil {
+		return err
+	}
+	var hist []languages.HistoryEntry
+	if *history != "" {
+		if hist, err = languages.ReadHistory(*history); err != nil {
+			return err
+		}
+		languages.SetIssueGrowth(list, hist)
+	}
+
+	s.mu.Lock()
+	s.list, s.hist, s.updated = list, hist, time.Now()
+	s.degraded = degradedReason()
+	s.mu.Unlock()
+	log.Println("drivers refreshed")
+	return nil
+}
+
+func (s *server) serveJSON(w http.ResponseWriter, r *http.Request) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetIndent("", "\t")
+	if err := enc.Encode(s.list); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	http.ServeContent(w, r, "", s.updated, bytes.NewReader(buf.Bytes()))
+}
+
+func (s *server) serveFormat(name, contentType string) http.HandlerFunc {
+	f := languages.Renderers[name]
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.RLock()
+		defer s.mu.RUnlock()
+
+		// copied, as several requests may be served at the same time
+		cols := append(append([]languages.Column{}, s.cols...), languages.ExtraColumns(s.list)...)
+		var buf bytes.Buffer
+		opts := documentOptions(cols, s.hist)
+		opts.Degraded = s.degraded
+		languages.WriteDocument(&buf, f, s.list, opts)
+		w.Header().Set("Content-Type", contentType)
+		http.ServeContent(w, r, "", s.updated, bytes.NewReader(buf.Bytes()))
+	}
+}
+
+func (s *server) serveMetrics(w http.ResponseWriter, r *http.Request) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	languages.WriteMetrics(w, s.list, s.updated)
+}
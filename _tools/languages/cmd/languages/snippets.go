@@ -0,0 +1,281 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"go/parser"
+	"go/scanner"
+	"go/token"
+	"io/ioutil"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/bblfsh/documentation/_tools/languages"
+)
+
+// skipMarker is an HTML comment that, placed in the line before a code
+// block, excludes it from the snippets check.
+const skipMarker = "<!-- snippet:skip -->"
+
+// snippet is a fenced code block of the documentation.
+type snippet struct {
+	File string
+	// Line is the line of the first line of code.
+	Line int
+	Lang string
+	Code string
+}
+
+// runSnippets implements the snippets subcommand, that extracts the code
+// blocks of the documentation and checks them. Complete Go programs are
+// built, Go fragments are type checked against the bblfsh client, and
+// Python and bash blocks are checked for syntax errors if the interpreters
+// are installed.
+func runSnippets(args []string) error {
+	fs := flag.NewFlagSet("snippets", flag.ExitOnError)
+	root := fs.String("root", ".", "root directory of the documentation")
+	exclude := fs.String("exclude", defaultExclude, "comma-separated globs of files not to check")
+	build := fs.Bool("build", true, "build complete Go programs and type check Go fragments, fetching their dependencies")
+	fs.Parse(args)
+
+	files, err := walkDocs(*root, splitList(*exclude))
+	if err != nil {
+		return err
+	}
+	var failed int
+	for _, f := range files {
+		snips, err := extractSnippets(*root, f)
+		if err != nil {
+			return err
+		}
+		for _, s := range snips {
+			var probs []languages.Problem
+			switch s.Lang {
+			case "go":
+				probs = checkGo(s, *build)
+			case "python":
+				probs = checkSyntax(s, "python3", "-c", "import ast, sys; ast.parse(sys.stdin.read())")
+			case "bash":
+				probs = checkSyntax(s, "bash", "-n")
+			}
+			for _, p := range probs {
+				reportError(p)
+			}
+			if len(probs) != 0 {
+				failed++
+			}
+		}
+	}
+	if failed != 0 {
+		return fmt.Errorf("%d code snippets failed", failed)
+	}
+	return nil
+}
+
+// extractSnippets returns the fenced code blocks of a markdown file that
+// have a language tag and are not marked to be skipped.
+func extractSnippets(root, file string) ([]snippet, error) {
+	f, err := os.Open(filepath.Join(root, filepath.FromSlash(file)))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var (
+		out   []snippet
+		cur   *snippet
+		code  bytes.Buffer
+		prev  string
+		fence string
+		n     int
+	)
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		n++
+		line := sc.Text()
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case fence != "":
+			if strings.HasPrefix(trimmed, fence) {
+				fence = ""
+				if cur != nil {
+					cur.Code = code.String()
+					out = append(out, *cur)
+					cur = nil
+				}
+			} else if cur != nil {
+				code.WriteString(line + "\n")
+			}
+		case strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~"):
+			fence = trimmed[:3]
+			lang := strings.TrimSpace(trimmed[3:])
+			if lang != "" && prev != skipMarker {
+				cur = &snippet{File: file, Line: n + 1, Lang: lang}
+				code.Reset()
+			}
+		}
+		if trimmed != "" {
+			prev = trimmed
+		}
+	}
+	return out, sc.Err()
+}
+
+// goScaffolds wrap Go fragments so they can be parsed as a file. They are
+// tried in order, and the offset is the number of lines added before the
+// fragment.
+var goScaffolds = []struct {
+	prefix, suffix string
+	offset         int
+}{
+	{"", "", 0},
+	{"package snippet\n", "", 1},
+	{"package snippet\nfunc _() {\n", "\n}\n", 2},
+	{"package snippet\nvar _ = ", "\n", 1},
+}
+
+// goClient is the module of the bblfsh client, that the Go fragments are
+// built against.
+const goClient = "gopkg.in/bblfsh/client-go.v2"
+
+func checkGo(s snippet, build bool) []languages.Problem {
+	if strings.HasPrefix(strings.TrimSpace(s.Code), "package ") {
+		if build {
+			return buildGo(s)
+		}
+	}
+
+	// report the error of the first scaffold, that is the complete file
+	var probs []languages.Problem
+	for _, sc := range goScaffolds {
+		src := sc.prefix + s.Code + sc.suffix
+		_, err := parser.ParseFile(token.NewFileSet(), "snippet.go", src, 0)
+		if err == nil {
+			if build && sc.offset != 0 {
+				return buildFragment(s, sc.prefix, sc.suffix)
+			}
+			return nil
+		}
+		if len(probs) == 0 {
+			probs = append(probs, goErrorAt(s, err, sc.offset))
+		}
+	}
+	return probs
+}
+
+// goErrorAt translates the position of a parsing error to the
+// documentation file.
+func goErrorAt(s snippet, err error, offset int) languages.Problem {
+	if list, ok := err.(scanner.ErrorList); ok && len(list) != 0 {
+		e := list[0]
+		return languages.Problem{File: s.File, Line: s.Line + e.Pos.Line - 1 - offset, Msg: "go: " + e.Msg}
+	}
+	return languages.Problem{File: s.File, Line: s.Line, Msg: "go: " + err.Error()}
+}
+
+var (
+	goBuildError    = regexp.MustCompile(`(?m)^\./main\.go:(\d+):(?:\d+:)? (.*)$`)
+	goFragmentError = regexp.MustCompile(`(?m)^(?:\./)?fragment\.go:(\d+):(?:\d+:)? (.*)$`)
+)
+
+// buildGo builds a complete Go program in a temporary module, resolving
+// its dependencies.
+func buildGo(s snippet) []languages.Problem {
+	return goModule(s, "main.go", s.Code, goBuildError, [][]string{
+		{"go", "mod", "init", "snippet"},
+		{"go", "mod", "tidy"},
+		{"go", "build", "-o", os.DevNull, "."},
+	})
+}
+
+// buildFragment builds a Go fragment in the scaffold it parses with,
+// in a temporary module that requires the bblfsh client, so the examples
+// are checked against its current API. The imports of the fragment are
+// added by goimports, and the check is skipped if it is not installed.
+// The variables the fragment declares but does not use are not reported,
+// since the examples are often left unfinished.
+func buildFragment(s snippet, prefix, suffix string) []languages.Problem {
+	if _, err := exec.LookPath("goimports"); err != nil {
+		log.Printf("%s:%d: not building go snippet: goimports not found", s.File, s.Line)
+		return nil
+	}
+	// the line directive keeps the positions of the errors relative to the
+	// fragment, whatever the imports added before it
+	src := prefix + "\n//line fragment.go:1\n" + s.Code + suffix
+	probs := goModule(s, "fragment.go", src, goFragmentError, [][]string{
+		{"go", "mod", "init", "snippet"},
+		{"go", "get", goClient},
+		{"goimports", "-w", "fragment.go"},
+		{"go", "mod", "tidy"},
+		// the compiler reports all the errors, unlike vet
+		{"go", "build", "-o", os.DevNull, "."},
+	})
+	out := probs[:0]
+	for _, p := range probs {
+		if !strings.Contains(p.Msg, "declared and not used") {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// goModule writes the source to a file of a temporary module and runs the
+// commands in it, reporting the errors of the first one that fails. The
+// errors matching errLine are reported at their line of the snippet.
+func goModule(s snippet, file, src string, errLine *regexp.Regexp, cmds [][]string) []languages.Problem {
+	dir, err := ioutil.TempDir("", "snippet")
+	if err != nil {
+		return []languages.Problem{{File: s.File, Line: s.Line, Msg: err.Error()}}
+	}
+	defer os.RemoveAll(dir)
+
+	if err := ioutil.WriteFile(filepath.Join(dir, file), []byte(src), 0644); err != nil {
+		return []languages.Problem{{File: s.File, Line: s.Line, Msg: err.Error()}}
+	}
+	for _, args := range cmds {
+		cmd := exec.Command(args[0], args[1:]...)
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		if err == nil {
+			continue
+		}
+		var probs []languages.Problem
+		for _, m := range errLine.FindAllStringSubmatch(string(out), -1) {
+			line, _ := strconv.Atoi(m[1])
+			probs = append(probs, languages.Problem{File: s.File, Line: s.Line + line - 1, Msg: "go: " + m[2]})
+		}
+		if len(probs) == 0 {
+			probs = append(probs, languages.Problem{
+				File: s.File,
+				Line: s.Line,
+				Msg:  fmt.Sprintf("%s %s: %s", args[0], args[1], bytes.TrimSpace(out)),
+			})
+		}
+		return probs
+	}
+	return nil
+}
+
+// checkSyntax runs a syntax checker that reads the code from stdin. The
+// check is skipped if the checker is not installed.
+func checkSyntax(s snippet, name string, args ...string) []languages.Problem {
+	if _, err := exec.LookPath(name); err != nil {
+		log.Printf("%s:%d: skipping %s snippet: %s not found", s.File, s.Line, s.Lang, name)
+		return nil
+	}
+	cmd := exec.Command(name, args...)
+	cmd.Stdin = strings.NewReader(s.Code)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		// Report only the last line, that has the error message.
+		lines := strings.Split(string(bytes.TrimSpace(out)), "\n")
+		return []languages.Problem{{File: s.File, Line: s.Line, Msg: s.Lang + ": " + lines[len(lines)-1]}}
+	}
+	return nil
+}
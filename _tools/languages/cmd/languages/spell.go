@@ -0,0 +1,175 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/bblfsh/documentation/_tools/languages"
+	"gopkg.in/bblfsh/sdk.v1/manifest/discovery"
+)
+
+// runSpell implements the spell subcommand, that checks the spelling of
+// the prose in the documentation against a word list, the project
+// dictionary and the names of the discovered drivers.
+func runSpell(args []string) error {
+	fs := flag.NewFlagSet("spell", flag.ExitOnError)
+	root := fs.String("root", ".", "root directory of the documentation")
+	exclude := fs.String("exclude", defaultExclude+",uast/roles.md", "comma-separated globs of files not to check")
+	dict := fs.String("dict", "/usr/share/dict/words", "word list of the language, one word per line")
+	project := fs.String("project", ".spelling", "project dictionary, relative to the root")
+	drivers := fs.Bool("drivers", true, "accept the names of the official drivers")
+	fs.Parse(args)
+
+	words := make(dictionary)
+	if err := words.load(*dict); err != nil {
+		return err
+	}
+	if err := words.load(filepath.Join(*root, *project)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if *drivers {
+		langs, err := discovery.OfficialDrivers(runCtx, &discovery.Options{NamesOnly: true})
+		if err != nil {
+			return err
+		}
+		for _, d := range langs {
+			words.addText(d.Language)
+			words.addText(d.Name)
+		}
+	}
+
+	files, err := walkDocs(*root, splitList(*exclude))
+	if err != nil {
+		return err
+	}
+	var unknown int
+	for _, f := range files {
+		lines, err := proseLines(*root, f)
+		if err != nil {
+			return err
+		}
+		for i, line := range lines {
+			for _, w := range wordPattern.FindAllString(line, -1) {
+				if !words.accepts(w) {
+					reportError(languages.Problem{File: f, Line: i + 1, Msg: fmt.Sprintf("unknown word %q", w)})
+					unknown++
+				}
+			}
+		}
+	}
+	if unknown != 0 {
+		return fmt.Errorf("%d unknown words found, fix them or add them to %s", unknown, *project)
+	}
+	return nil
+}
+
+var wordPattern = regexp.MustCompile(`\pL[\pL'’]*`)
+
+// dictionary is a set of accepted words, in lowercase.
+type dictionary map[string]bool
+
+// load adds the words of a file with one word per line. Lines starting
+// with # are comments.
+func (d dictionary) load(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		d[strings.ToLower(line)] = true
+	}
+	return sc.Err()
+}
+
+// addText adds every word of a text, like a driver name.
+func (d dictionary) addText(s string) {
+	for _, w := range wordPattern.FindAllString(s, -1) {
+		d[strings.ToLower(w)] = true
+	}
+}
+
+func (d dictionary) accepts(w string) bool {
+	w = strings.Trim(strings.Replace(w, "’", "'", -1), "'")
+	if w == "" || isIdentifier(w) {
+		return true
+	}
+	lw := strings.ToLower(w)
+	return d[lw] || d[strings.TrimSuffix(lw, "'s")]
+}
+
+// isIdentifier reports whether a word looks like a code identifier, like
+// ParseResponse or startOffset, which are not spell checked.
+func isIdentifier(w string) bool {
+	var lower bool
+	for i, r := range w {
+		if unicode.IsLower(r) {
+			lower = true
+		} else if i > 0 && unicode.IsUpper(r) && lower {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	urlPattern  = regexp.MustCompile(`\b[a-z]+://\S+`)
+	linkTarget  = regexp.MustCompile(`\]\([^)]*\)`)
+	htmlTag     = regexp.MustCompile(`<[^>]*>`)
+	refLinkLine = regexp.MustCompile(`^\s{0,3}\[[^\]]+\]:`)
+)
+
+// proseLines returns the lines of a markdown file with everything that is
+// not prose blanked: code blocks, code spans, URLs, link targets and HTML
+// tags. Line numbers are preserved.
+func proseLines(root, file string) ([]string, error) {
+	f, err := os.Open(filepath.Join(root, filepath.FromSlash(file)))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var (
+		lines []string
+		fence string
+	)
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		line := sc.Text()
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case fence != "":
+			if strings.HasPrefix(trimmed, fence) {
+				fence = ""
+			}
+			line = ""
+		case strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~"):
+			fence = trimmed[:3]
+			line = ""
+		case strings.HasPrefix(line, "    ") || strings.HasPrefix(line, "\t"):
+			// indented code block
+			line = ""
+		case refLinkLine.MatchString(line):
+			line = ""
+		default:
+			line = codeSpan.ReplaceAllString(line, "")
+			line = linkTarget.ReplaceAllString(line, "]")
+			line = urlPattern.ReplaceAllString(line, "")
+			line = htmlTag.ReplaceAllString(line, "")
+		}
+		lines = append(lines, line)
+	}
+	return lines, sc.Err()
+}
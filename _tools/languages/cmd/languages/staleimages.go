@@ -0,0 +1,44 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/bblfsh/documentation/_tools/languages"
+)
+
+// runStaleImages implements the stale-images subcommand, that reports the
+// driver images in Docker Hub that do not belong to any of the drivers
+// selected with the flags of the main command.
+func runStaleImages(args []string) error {
+	fs := flag.NewFlagSet("stale-images", flag.ExitOnError)
+	out := fs.String("o", "md", "output format (md or html)")
+	fs.Parse(args)
+
+	f, ok := languages.Renderers[*out]
+	if !ok {
+		return fmt.Errorf("unknown output format: %q", *out)
+	}
+	ctx := runCtx
+	opts := discoverOptions()
+	// the images of the fallback would all be known
+	opts.Fallback = nil
+	list, err := languages.Discover(ctx, opts)
+	if err != nil {
+		return err
+	}
+	var reg languages.RegistryChecker
+	if *mirrors != "" {
+		reg = languages.NewDockerHub(splitList(*mirrors)...)
+	}
+	imgs, err := languages.FindStaleImages(ctx, list, reg)
+	if err != nil {
+		return err
+	}
+	for _, img := range imgs {
+		reportWarning(languages.Problem{Msg: img.Image + ": no driver uses this image"})
+	}
+	languages.WriteStaleImages(os.Stdout, f, imgs)
+	return nil
+}
@@ -0,0 +1,233 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/bblfsh/documentation/_tools/languages"
+)
+
+// errStale is returned by the check modes when a generated file is not
+// up to date.
+var errStale = errors.New("generated file is not up to date")
+
+// runTOC implements the toc subcommand, that regenerates the GitBook
+// SUMMARY.md from the markdown files of the documentation.
+//
+// The structure of the existing summary is kept: its sections, the order
+// and titles of its entries, and external links. Entries whose files no
+// longer exist are removed, and new files are added to the section with
+// other files of the same directory, titled after their first heading.
+// Files in a directory with the same name as an entry, like the driver
+// pages in languages/, are nested under that entry.
+func runTOC(args []string) error {
+	fs := flag.NewFlagSet("toc", flag.ExitOnError)
+	root := fs.String("root", ".", "root directory of the documentation")
+	exclude := fs.String("exclude", defaultExclude, "comma-separated globs of files to leave out of the summary")
+	check := fs.Bool("check", false, "do not write the summary, fail if it is not up to date")
+	fs.Parse(args)
+
+	summaryPath := filepath.Join(*root, "SUMMARY.md")
+	old, err := ioutil.ReadFile(summaryPath)
+	if err != nil {
+		return err
+	}
+	toc := parseSummary(old)
+
+	files, err := walkDocs(*root, splitList(*exclude))
+	if err != nil {
+		return err
+	}
+	if err := toc.update(*root, files); err != nil {
+		return err
+	}
+
+	data := toc.Bytes()
+	if *check {
+		if !bytes.Equal(old, data) {
+			reportError(languages.Problem{
+				File: summaryPath,
+				Line: firstDiffLine(old, data),
+				Msg:  "not up to date, run 'make toc'",
+			})
+			return errStale
+		}
+		return nil
+	}
+	return ioutil.WriteFile(summaryPath, data, 0644)
+}
+
+type tocEntry struct {
+	Title    string
+	Path     string
+	Children []*tocEntry
+}
+
+func (e *tocEntry) external() bool {
+	return strings.Contains(e.Path, "://")
+}
+
+type tocSection struct {
+	// Title is empty for the entries before the first section.
+	Title   string
+	Entries []*tocEntry
+}
+
+type summary struct {
+	Title    string
+	Sections []*tocSection
+}
+
+var tocLine = regexp.MustCompile(`^(\s*)[*-]\s+\[([^\]]*)\]\(([^)]*)\)`)
+
+func parseSummary(data []byte) *summary {
+	s := &summary{Title: "Summary"}
+	cur := &tocSection{}
+	s.Sections = append(s.Sections, cur)
+
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	for sc.Scan() {
+		line := sc.Text()
+		switch {
+		case strings.HasPrefix(line, "## "):
+			cur = &tocSection{Title: strings.TrimSpace(line[3:])}
+			s.Sections = append(s.Sections, cur)
+		case strings.HasPrefix(line, "# "):
+			s.Title = strings.TrimSpace(line[2:])
+		default:
+			m := tocLine.FindStringSubmatch(line)
+			if m == nil {
+				continue
+			}
+			e := &tocEntry{Title: m[2], Path: m[3]}
+			if n := len(cur.Entries); m[1] != "" && n != 0 {
+				parent := cur.Entries[n-1]
+				parent.Children = append(parent.Children, e)
+			} else {
+				cur.Entries = append(cur.Entries, e)
+			}
+		}
+	}
+	return s
+}
+
+// update removes the entries of missing files and adds the new ones.
+func (s *summary) update(root string, files []string) error {
+	exists := make(map[string]bool, len(files))
+	for _, f := range files {
+		exists[f] = true
+	}
+
+	known := make(map[string]bool)
+	var keep func(list []*tocEntry) []*tocEntry
+	keep = func(list []*tocEntry) []*tocEntry {
+		out := list[:0]
+		for _, e := range list {
+			if !e.external() && !exists[path.Clean(e.Path)] {
+				continue
+			}
+			known[path.Clean(e.Path)] = true
+			e.Children = keep(e.Children)
+			out = append(out, e)
+		}
+		return out
+	}
+	for _, sec := range s.Sections {
+		sec.Entries = keep(sec.Entries)
+	}
+
+	sort.Strings(files)
+	for _, f := range files {
+		if known[f] || f == "SUMMARY.md" || f == "README.md" {
+			continue
+		}
+		title, err := docTitle(filepath.Join(root, filepath.FromSlash(f)))
+		if err != nil {
+			return err
+		}
+		if title == "" {
+			title = strings.TrimSuffix(path.Base(f), ".md")
+		}
+		e := &tocEntry{Title: title, Path: f}
+
+		if parent := s.find(path.Dir(f) + ".md"); parent != nil {
+			parent.Children = append(parent.Children, e)
+			continue
+		}
+		sec := s.sectionFor(path.Dir(f))
+		sec.Entries = append(sec.Entries, e)
+	}
+	return nil
+}
+
+func (s *summary) find(p string) *tocEntry {
+	var find func(list []*tocEntry) *tocEntry
+	find = func(list []*tocEntry) *tocEntry {
+		for _, e := range list {
+			if path.Clean(e.Path) == p {
+				return e
+			}
+			if c := find(e.Children); c != nil {
+				return c
+			}
+		}
+		return nil
+	}
+	for _, sec := range s.Sections {
+		if e := find(sec.Entries); e != nil {
+			return e
+		}
+	}
+	return nil
+}
+
+// sectionFor returns the first section with files in dir, or a new one
+// titled after the directory.
+func (s *summary) sectionFor(dir string) *tocSection {
+	if dir == "." {
+		return s.Sections[0]
+	}
+	for _, sec := range s.Sections {
+		for _, e := range sec.Entries {
+			if !e.external() && path.Dir(path.Clean(e.Path)) == dir {
+				return sec
+			}
+		}
+	}
+	sec := &tocSection{Title: strings.Title(path.Base(dir))}
+	s.Sections = append(s.Sections, sec)
+	return sec
+}
+
+func (s *summary) Bytes() []byte {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "# %s\n", s.Title)
+
+	var write func(list []*tocEntry, indent string)
+	write = func(list []*tocEntry, indent string) {
+		for _, e := range list {
+			fmt.Fprintf(&buf, "%s* [%s](%s)\n", indent, e.Title, e.Path)
+			write(e.Children, indent+"  ")
+		}
+	}
+	for _, sec := range s.Sections {
+		if sec.Title == "" && len(sec.Entries) == 0 {
+			continue
+		}
+		if sec.Title != "" {
+			fmt.Fprintf(&buf, "\n## %s\n", sec.Title)
+		}
+		buf.WriteString("\n")
+		write(sec.Entries, "")
+	}
+	return buf.Bytes()
+}
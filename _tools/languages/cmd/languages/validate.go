@@ -0,0 +1,52 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/bblfsh/documentation/_tools/languages"
+)
+
+// runValidate implements the validate subcommand, that checks the
+// manifests of the drivers selected with the flags of the main command
+// and writes a quality report. Official drivers with a score below the
+// threshold are errors, and the problems of the rest are warnings.
+func runValidate(args []string) error {
+	fs := flag.NewFlagSet("validate", flag.ExitOnError)
+	minScore := fs.Float64("min-score", 0.75, "minimum score, from 0 to 1, of the official drivers")
+	out := fs.String("o", "md", "output format (md or html)")
+	offline := fs.Bool("offline", false, "do not check that the documentation of the drivers exists")
+	fs.Parse(args)
+
+	f, ok := languages.Renderers[*out]
+	if !ok {
+		return fmt.Errorf("unknown output format: %q", *out)
+	}
+	list, err := languages.Discover(runCtx, discoverOptions())
+	if err != nil {
+		return err
+	}
+
+	var cli *http.Client
+	if !*offline {
+		cli = languages.NewHTTPClient(time.Minute)
+	}
+	qs := make([]languages.Quality, 0, len(list))
+	for _, d := range list {
+		q := languages.ValidateDriver(cli, d)
+		qs = append(qs, q)
+
+		report := reportWarning
+		if !d.Community && q.Score < *minScore {
+			report = reportError
+		}
+		for _, p := range q.Problems {
+			report(languages.Problem{Msg: d.Language + ": " + p})
+		}
+	}
+	languages.WriteQuality(os.Stdout, f, qs)
+	return nil
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"regexp"
+	"sort"
+	"time"
+
+	"github.com/bblfsh/documentation/_tools/languages"
+)
+
+// verifyConcurrency is the number of concurrent requests that verify the
+// generated links, low to avoid being rate limited by GitHub and Docker Hub.
+const verifyConcurrency = 4
+
+// generatedURL matches the URLs in a generated document, both in markdown
+// links and HTML attributes.
+var generatedURL = regexp.MustCompile(`https?://[^\s()<>"'\]]+`)
+
+// verifyLinks checks the URLs of a generated document, like the ones of
+// the driver repositories, images and maintainers, that break when they
+// are renamed or deleted. The dead ones are reported as warnings, or as
+// errors with -strict.
+func verifyLinks(name string, doc []byte) {
+	byURL := make(map[string][]docLink)
+	for i, line := range bytes.Split(doc, []byte("\n")) {
+		for _, url := range generatedURL.FindAll(line, -1) {
+			byURL[string(url)] = append(byURL[string(url)], docLink{File: name, Line: i + 1, URL: string(url)})
+		}
+	}
+	log.Println("verifying", len(byURL), "generated links")
+	c := &urlChecker{cli: languages.NewHTTPClient(30 * time.Second), retries: 2}
+	dead := c.checkAll(byURL, verifyConcurrency)
+
+	report := reportWarning
+	if *strict {
+		report = reportError
+	}
+	urls := make([]string, 0, len(dead))
+	for url := range dead {
+		urls = append(urls, url)
+	}
+	sort.Strings(urls)
+	for _, url := range urls {
+		l := byURL[url][0]
+		report(languages.Problem{File: l.File, Line: l.Line, Msg: fmt.Sprintf("dead link %s: %v", url, dead[url])})
+	}
+}
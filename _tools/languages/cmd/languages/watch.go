@@ -0,0 +1,58 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"time"
+
+	"github.com/bblfsh/documentation/_tools/languages"
+)
+
+// runWatch generates the document into path every interval, until the
+// process is stopped. The file is only rewritten if its content changes,
+// and the changes of the drivers are logged. Failed runs are logged and
+// retried on the next interval.
+func runWatch(path string, interval time.Duration) error {
+	var prev []languages.Driver
+	for {
+		list, err := writeOutput(path)
+		if err != nil {
+			log.Println("cannot regenerate", path+":", err)
+		} else {
+			if prev != nil {
+				for _, c := range languages.DiffDrivers(prev, list) {
+					log.Println(c.Summary)
+				}
+			}
+			prev = list
+		}
+		log.Println("next run in", interval)
+		time.Sleep(interval)
+	}
+}
+
+// writeOutput generates the document into path, only rewriting it if its
+// content, other than the metadata, changes.
+func writeOutput(path string) ([]languages.Driver, error) {
+	var buf bytes.Buffer
+	list, err := run(&buf)
+	if err != nil {
+		return nil, err
+	}
+	old, err := ioutil.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	if err == nil && bytes.Equal(languages.StripMetadata(old), languages.StripMetadata(buf.Bytes())) {
+		log.Println(path, "is up to date")
+		return list, nil
+	}
+	if err := ioutil.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		return nil, fmt.Errorf("cannot write %s: %v", path, err)
+	}
+	log.Println(path, "updated")
+	return list, nil
+}
@@ -0,0 +1,266 @@
+package languages
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/bblfsh/sdk.v1/manifest"
+)
+
+// Column describes a single column of the generated table.
+type Column struct {
+	Header string
+	// Cell renders the value of the column for a driver.
+	Cell func(f Renderer, d Driver) string
+}
+
+var defaultColumns = []Column{
+	{Header: "Language", Cell: func(f Renderer, d Driver) string {
+		name := d.Name
+		if name == "" {
+			name = d.Language
+		}
+		if d.Deprecated != "" {
+			return f.Link(name, d.RepoURL()) + " " + f.Badge("deprecated", colorRed)
+		}
+		return f.Link(name, d.RepoURL())
+	}},
+	{Header: "Key", Cell: textCell(func(d Driver) string { return d.Language })},
+	{Header: "Status", Cell: textCell(func(d Driver) string { return string(d.Status) })},
+	{Header: "Version", Cell: textCell(func(d Driver) string { return orDash(d.LatestVersion) })},
+	// which versions of the language are parsed is a common question
+	{Header: "Language versions", Cell: textCell(func(d Driver) string { return orDash(d.languageVersions()) })},
+	{Header: "Last updated", Cell: textCell(func(d Driver) string { return formatDate(d.LastCommit) })},
+	{Header: "Build", Cell: textCell(func(d Driver) string { return orDash(d.BuildStatus) })},
+	{Header: "AST*", Cell: featureCell(manifest.AST)},
+	{Header: "UAST**", Cell: featureCell(manifest.UAST)},
+	{Header: "Annotations***", Cell: featureCell(manifest.Roles)},
+	{Header: "Container", Cell: containerCell},
+	{Header: "License", Cell: textCell(func(d Driver) string { return orDash(d.License) })},
+	{Header: "Maintainers", Cell: maintainerCell},
+}
+
+// optionalColumns are the columns that can be enabled with SelectColumns. They
+// are appended after the default ones.
+var optionalColumns = map[string]Column{
+	"activity": {Header: "Activity", Cell: activityCell},
+	"aliases": {Header: "Aliases", Cell: textCell(func(d Driver) string {
+		return orDash(strings.Join(d.Aliases, ", "))
+	})},
+	"arch": {Header: "Architectures", Cell: textCell(func(d Driver) string {
+		return orDash(strings.Join(d.Architectures, ", "))
+	})},
+	"coverage": {Header: "Coverage", Cell: func(f Renderer, d Driver) string {
+		if d.Coverage == nil {
+			return f.Text("-")
+		}
+		return f.Badge(fmt.Sprintf("%.0f%%", *d.Coverage), coverageColor(*d.Coverage))
+	}},
+	"cves": {Header: "CVEs", Cell: func(f Renderer, d Driver) string {
+		v := d.Vulnerabilities
+		if v == nil {
+			return f.Text("-")
+		}
+		text := fmt.Sprintf("%d critical, %d high", v.Critical, v.High)
+		switch {
+		case v.Critical != 0:
+			return f.Badge(text, colorRed)
+		case v.High != 0:
+			return f.Badge(text, colorYellow)
+		}
+		return f.Badge(text, colorGreen)
+	}},
+	"digest": {Header: "Image digest", Cell: textCell(func(d Driver) string {
+		return orDash(d.Digest)
+	})},
+	"examples": {Header: "Examples", Cell: examplesCell},
+	"features": {Header: "Other features", Cell: textCell(func(d Driver) string {
+		return orDash(strings.Join(d.otherFeatures(), ", "))
+	})},
+	"fixtures": {Header: "Fixtures", Cell: textCell(func(d Driver) string {
+		if d.Fixtures == 0 {
+			return "-"
+		}
+		return strconv.Itoa(d.Fixtures)
+	})},
+	"forks": {Header: "Forks", Cell: textCell(func(d Driver) string {
+		return strconv.Itoa(d.Forks)
+	})},
+	"installed": {Header: "Installed", Cell: installedCell},
+	"protocol": {Header: "Protocol", Cell: textCell(func(d Driver) string {
+		return orDash(strings.Join(d.Protocols, ", "))
+	})},
+	"pulls": {Header: "Pulls", Cell: textCell(func(d Driver) string {
+		if d.DockerhubURL == "" {
+			return "-"
+		}
+		return strconv.FormatInt(d.PullCount, 10)
+	})},
+	"pushed": {Header: "Last push", Cell: textCell(func(d Driver) string {
+		return formatDate(d.ImagePushed)
+	})},
+	"release": {Header: "Release", Cell: textCell(func(d Driver) string {
+		return orDash(d.LatestRelease)
+	})},
+	"released": {Header: "Released", Cell: textCell(func(d Driver) string {
+		return formatDate(d.ReleaseDate)
+	})},
+	"size": {Header: "Image size", Cell: textCell(func(d Driver) string {
+		if d.ImageSize == 0 {
+			return "-"
+		}
+		return humanSize(d.ImageSize)
+	})},
+	"stars": {Header: "Stars", Cell: textCell(func(d Driver) string {
+		return strconv.Itoa(d.Stars)
+	})},
+}
+
+// featurePrefix selects a column for a single manifest feature, named
+// after it, like "feature:roles".
+const featurePrefix = "feature:"
+
+// defaultFeatures are the features that have a column in the default table.
+var defaultFeatures = []manifest.Feature{manifest.AST, manifest.UAST, manifest.Roles}
+
+// SelectColumns returns the default columns followed by the optional ones
+// listed in names, in the order given. Besides the names returned by
+// OptionalColumnNames, "feature:<name>" adds a column for any feature the
+// manifests may declare, so new features of the SDK need no new columns.
+func SelectColumns(names string) ([]Column, error) {
+	cols := append([]Column{}, defaultColumns...)
+	if names == "" {
+		return cols, nil
+	}
+	for _, name := range strings.Split(names, ",") {
+		name = strings.TrimSpace(name)
+		if strings.HasPrefix(name, featurePrefix) {
+			ft := strings.TrimPrefix(name, featurePrefix)
+			if ft == "" {
+				return nil, fmt.Errorf("missing feature name in column %q", name)
+			}
+			cols = append(cols, Column{Header: featureHeader(ft), Cell: featureCell(manifest.Feature(ft))})
+			continue
+		}
+		c, ok := optionalColumns[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown column %q (available: %s)",
+				name, strings.Join(OptionalColumnNames(), ", "))
+		}
+		cols = append(cols, c)
+	}
+	return cols, nil
+}
+
+// OptionalColumnNames returns the names of the optional columns, sorted.
+func OptionalColumnNames() []string {
+	names := make([]string, 0, len(optionalColumns))
+	for name := range optionalColumns {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// textCell returns a cell function that renders plain text.
+func textCell(fn func(d Driver) string) func(f Renderer, d Driver) string {
+	return func(f Renderer, d Driver) string {
+		return f.Text(fn(d))
+	}
+}
+
+// maintainerCell renders the links to all the maintainers of the driver.
+func maintainerCell(f Renderer, d Driver) string {
+	if len(d.Maintainers) == 0 {
+		return f.Text("-")
+	}
+	links := make([]string, 0, len(d.Maintainers))
+	for _, mnt := range d.Maintainers {
+		links = append(links, maintainerLink(f, mnt))
+	}
+	return f.List(links)
+}
+
+// containerCell links to the image of the driver, with the result of its
+// smoke test if it was run.
+func containerCell(f Renderer, d Driver) string {
+	if d.DockerhubURL == "" || d.ImageWorks == nil {
+		return linkMark(f, d.DockerhubURL)
+	}
+	if *d.ImageWorks {
+		return f.Mark(true, d.DockerhubURL) + " " + f.Badge("works", colorGreen)
+	}
+	return f.Mark(false, d.DockerhubURL) + " " + f.Badge("broken", colorRed)
+}
+
+func featureCell(feature manifest.Feature) func(f Renderer, d Driver) string {
+	return func(f Renderer, d Driver) string {
+		return f.Mark(d.Supports(feature), "")
+	}
+}
+
+// featureHeader returns the header of the column of a feature, like
+// "Semantic annotations" for "semantic-annotations".
+func featureHeader(ft string) string {
+	h := strings.NewReplacer("-", " ", "_", " ").Replace(ft)
+	return strings.ToUpper(h[:1]) + h[1:]
+}
+
+func headers(cols []Column) []string {
+	out := make([]string, 0, len(cols))
+	for _, c := range cols {
+		out = append(out, c.Header)
+	}
+	return out
+}
+
+func tableRows(f Renderer, list []Driver, cols []Column) [][]string {
+	rows := make([][]string, 0, len(list))
+	for _, d := range list {
+		row := make([]string, 0, len(cols))
+		for _, c := range cols {
+			row = append(row, c.Cell(f, d))
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+func formatDate(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return t.Format("2006-01-02")
+}
+
+// humanSize formats a size in bytes using decimal units, like Docker Hub.
+func humanSize(n int64) string {
+	const unit = 1000
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "kMGTPE"[exp])
+}
+
+// examplesCell links to the native AST and UAST of a fixture of the driver.
+func examplesCell(f Renderer, d Driver) string {
+	if d.NativeExampleURL == "" {
+		return f.Text("-")
+	}
+	return f.Link("native", d.NativeExampleURL) + ", " + f.Link("UAST", d.UASTExampleURL)
+}
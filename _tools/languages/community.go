@@ -0,0 +1,91 @@
+package languages
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"gopkg.in/bblfsh/sdk.v1/manifest"
+	"gopkg.in/bblfsh/sdk.v1/manifest/discovery"
+	"gopkg.in/yaml.v2"
+)
+
+// communityDriver is an entry of the community drivers file.
+type communityDriver struct {
+	// Github is the URL of the driver repository.
+	Github string `yaml:"github"`
+	// Image is the name of the driver image in Docker Hub.
+	Image string `yaml:"image"`
+
+	// The following fields override the ones in the driver manifest, and
+	// are required if the repository has no manifest.
+	Language    string                 `yaml:"language"`
+	Name        string                 `yaml:"name"`
+	Status      string                 `yaml:"status"`
+	Aliases     []string               `yaml:"aliases"`
+	Extensions  []string               `yaml:"extensions"`
+	Maintainers []discovery.Maintainer `yaml:"maintainers"`
+}
+
+// loadCommunity reads the list of community drivers from a YAML file. The
+// manifest of each driver is read from its repository, as the discovery
+// does for the official ones, and drivers with an invalid one are reported
+// and skipped.
+func loadCommunity(g *githubClient, path string, report func(Problem)) ([]Driver, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var entries []communityDriver
+	if err := yaml.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+
+	list := make([]Driver, 0, len(entries))
+	for _, e := range entries {
+		if !strings.HasPrefix(e.Github, "https://github.com/") {
+			return nil, fmt.Errorf("%s: driver repository must be on GitHub: %q", path, e.Github)
+		}
+		d := Driver{
+			Community: true,
+			Source:    sourceGithub,
+			GithubURL: strings.TrimSuffix(e.Github, "/"),
+			Image:     e.Image,
+		}
+
+		data, err := g.rawFile(repoPath(&d), "HEAD", "manifest.toml")
+		if err == nil {
+			if err := d.Manifest.Decode(bytes.NewReader(data)); err != nil {
+				report(Problem{File: path, Msg: fmt.Sprintf("%s: invalid manifest: %v", e.Github, err)})
+				continue
+			}
+		} else if err != errNotFound {
+			return nil, err
+		}
+
+		if e.Language != "" {
+			d.Language = e.Language
+		}
+		if e.Name != "" {
+			d.Name = e.Name
+		}
+		if len(e.Aliases) != 0 {
+			d.Aliases = e.Aliases
+		}
+		if len(e.Extensions) != 0 {
+			d.Extensions = e.Extensions
+		}
+		setManifestLists(&d, data)
+		if e.Status != "" {
+			d.Status = manifest.DevelopmentStatus(e.Status)
+		}
+		if d.Language == "" {
+			return nil, fmt.Errorf("%s: no language set for %s", path, e.Github)
+		}
+		d.Maintainers = newMaintainers(e.Maintainers)
+
+		list = append(list, d)
+	}
+	return list, nil
+}
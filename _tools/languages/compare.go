@@ -0,0 +1,49 @@
+package languages
+
+import (
+	"fmt"
+	"io"
+)
+
+// changeGroups are the sections of the change report, with the kinds of
+// changes listed in each one.
+var changeGroups = []struct {
+	Title string
+	Kinds []string
+}{
+	{"New drivers", []string{ChangeNew}},
+	{"Removed drivers", []string{ChangeRemoved}},
+	{"Status changes", []string{ChangeStatus}},
+	{"Features", []string{ChangeUAST, ChangeFeature, ChangeNoUAST, ChangeNoFeature}},
+	{"Container images", []string{ChangeImage, ChangeNoImage}},
+}
+
+// WriteChanges writes a report of the changes between two runs, grouped by
+// their kind, to be included in the release notes.
+func WriteChanges(w io.Writer, f Renderer, changes []Change) {
+	fmt.Fprint(w, f.Header())
+	defer fmt.Fprint(w, f.Footer())
+
+	f.Heading(w, "Driver changes")
+	if len(changes) == 0 {
+		f.Paragraph(w, f.Text("No driver has changed."))
+		return
+	}
+	for _, g := range changeGroups {
+		var group []Change
+		for _, kind := range g.Kinds {
+			for _, c := range changes {
+				if c.Kind == kind {
+					group = append(group, c)
+				}
+			}
+		}
+		if len(group) == 0 {
+			continue
+		}
+		f.Subheading(w, g.Title)
+		for _, c := range group {
+			f.Paragraph(w, f.Text(c.Summary+"."))
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package languages
+
+import (
+	"net/http"
+	"time"
+)
+
+const (
+	codecovAPI   = "https://api.codecov.io/api/v2/github/"
+	coverallsURL = "https://coveralls.io/github/"
+)
+
+func newCoverage() *coverageClient {
+	return &coverageClient{cli: NewHTTPClient(time.Minute)}
+}
+
+// coverageClient gets the line coverage of the driver repositories from
+// Codecov, falling back to Coveralls for repositories not using it.
+type coverageClient struct {
+	cli *http.Client
+}
+
+// loadCoverage sets the coverage of the driver, if any of the services
+// knows about its repository.
+func (c *coverageClient) loadCoverage(d *Driver) {
+	if d.GithubURL == "" {
+		return
+	}
+	repo := repoPath(d)
+	for _, get := range []func(string) (float64, error){c.codecov, c.coveralls} {
+		v, err := get(repo)
+		if err == errNotFound {
+			continue
+		} else if err != nil {
+			enrichFailed("coverage", "cannot get coverage of %s: %v", repo, err)
+			continue
+		}
+		d.Coverage = &v
+		return
+	}
+}
+
+func (c *coverageClient) codecov(repo string) (float64, error) {
+	owner, name := splitRepo(repo)
+	req, err := http.NewRequest("GET", codecovAPI+owner+"/repos/"+name+"/", nil)
+	if err != nil {
+		return 0, err
+	}
+
+	var r struct {
+		Totals *struct {
+			Coverage float64 `json:"coverage"`
+		} `json:"totals"`
+	}
+	if err := getJSON(c.cli, req, &r); err != nil {
+		return 0, err
+	}
+	if r.Totals == nil {
+		// the repository is activated, but has no reports yet
+		return 0, errNotFound
+	}
+	return r.Totals.Coverage, nil
+}
+
+func (c *coverageClient) coveralls(repo string) (float64, error) {
+	req, err := http.NewRequest("GET", coverallsURL+repo+".json", nil)
+	if err != nil {
+		return 0, err
+	}
+
+	var r struct {
+		CoveredPercent *float64 `json:"covered_percent"`
+	}
+	if err := getJSON(c.cli, req, &r); err != nil {
+		return 0, err
+	}
+	if r.CoveredPercent == nil {
+		return 0, errNotFound
+	}
+	return *r.CoveredPercent, nil
+}
+
+// coverageColor returns the badge color for a coverage percentage.
+func coverageColor(v float64) string {
+	switch {
+	case v >= 80:
+		return colorGreen
+	case v >= 60:
+		return colorYellow
+	default:
+		return colorRed
+	}
+}
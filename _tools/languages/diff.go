@@ -0,0 +1,105 @@
+package languages
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"gopkg.in/bblfsh/sdk.v1/manifest"
+)
+
+// Kinds of changes between two runs.
+const (
+	ChangeNew     = "new"
+	ChangeRemoved = "removed"
+	ChangeStatus  = "status"
+	ChangeImage   = "image"
+	ChangeNoImage = "no-image"
+	ChangeUAST    = "uast"
+	ChangeNoUAST  = "no-uast"
+	// ChangeFeature and ChangeNoFeature are the changes of the features
+	// other than UAST.
+	ChangeFeature   = "feature"
+	ChangeNoFeature = "no-feature"
+)
+
+// Change is a difference of a driver between a previous run and the
+// current one.
+type Change struct {
+	Language string
+	Kind     string
+	// Summary describes the change in a sentence.
+	Summary string
+}
+
+// ReadDrivers reads the drivers of the JSON output of a previous run.
+func ReadDrivers(path string) ([]Driver, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var list []Driver
+	if err := json.NewDecoder(file).Decode(&list); err != nil {
+		return nil, fmt.Errorf("cannot decode snapshot %s: %v", path, err)
+	}
+	return list, nil
+}
+
+// DiffDrivers returns the changes of status, image and features from the
+// old list of drivers to the new one, in the order of the new list.
+func DiffDrivers(old, cur []Driver) []Change {
+	prev := make(map[string]Driver, len(old))
+	for _, d := range old {
+		prev[d.Language] = d
+	}
+
+	var out []Change
+	add := func(d Driver, kind, format string, args ...interface{}) {
+		out = append(out, Change{
+			Language: d.Language,
+			Kind:     kind,
+			Summary:  fmt.Sprintf(format, args...),
+		})
+	}
+	for _, d := range cur {
+		p, ok := prev[d.Language]
+		if !ok {
+			add(d, ChangeNew, "New %s driver, with status %s", d.Language, orDash(string(d.Status)))
+			continue
+		}
+		delete(prev, d.Language)
+
+		if p.Status != d.Status {
+			add(d, ChangeStatus, "The %s driver changed its status from %s to %s",
+				d.Language, orDash(string(p.Status)), orDash(string(d.Status)))
+		}
+		if p.DockerhubURL == "" && d.DockerhubURL != "" {
+			add(d, ChangeImage, "The %s driver has a container image: %s", d.Language, d.Image)
+		} else if p.DockerhubURL != "" && d.DockerhubURL == "" {
+			add(d, ChangeNoImage, "The %s driver has no container image anymore", d.Language)
+		}
+		if was, is := p.Supports(manifest.UAST), d.Supports(manifest.UAST); !was && is {
+			add(d, ChangeUAST, "The %s driver supports UAST", d.Language)
+		} else if was && !is {
+			add(d, ChangeNoUAST, "The %s driver does not support UAST anymore", d.Language)
+		}
+		for _, ft := range p.Features {
+			if ft != manifest.UAST && !d.Supports(ft) {
+				add(d, ChangeNoFeature, "The %s driver does not support %s anymore", d.Language, ft)
+			}
+		}
+		for _, ft := range d.Features {
+			if ft != manifest.UAST && !p.Supports(ft) {
+				add(d, ChangeFeature, "The %s driver supports %s", d.Language, ft)
+			}
+		}
+	}
+	for _, d := range old {
+		if _, ok := prev[d.Language]; ok {
+			add(d, ChangeRemoved, "The %s driver was removed", d.Language)
+		}
+	}
+	return out
+}
@@ -0,0 +1,52 @@
+package languages
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+// Digest is the email summarizing the changes of the drivers since a
+// snapshot, sent to the maintainers every week.
+type Digest struct {
+	From    string
+	To      []string
+	Date    time.Time
+	Changes []Change
+}
+
+// Subject returns the subject of the digest email.
+func (d Digest) Subject() string {
+	return fmt.Sprintf("Babelfish drivers digest: %s", plural(len(d.Changes), "change"))
+}
+
+// WriteDigest writes the digest as an email with an HTML body, with the
+// changes grouped as in WriteChanges. The output can be piped to
+// "sendmail -t".
+func WriteDigest(w io.Writer, d Digest) error {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "From: %s\n", d.From)
+	fmt.Fprintf(&buf, "To: %s\n", strings.Join(d.To, ", "))
+	fmt.Fprintf(&buf, "Subject: %s\n", d.Subject())
+	fmt.Fprintf(&buf, "Date: %s\n", d.Date.Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\n")
+	buf.WriteString("Content-Type: text/html; charset=UTF-8\n\n")
+	WriteChanges(&buf, HTML{}, d.Changes)
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+// SendDigest sends the digest through the SMTP server at addr, like
+// "smtp.example.com:587". auth may be nil for servers that need none.
+func SendDigest(addr string, auth smtp.Auth, d Digest) error {
+	var buf bytes.Buffer
+	if err := WriteDigest(&buf, d); err != nil {
+		return err
+	}
+	// SMTP requires CRLF line endings
+	msg := bytes.Replace(buf.Bytes(), []byte("\n"), []byte("\r\n"), -1)
+	return smtp.SendMail(addr, auth, d.From, d.To, msg)
+}
@@ -0,0 +1,320 @@
+package languages
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/heroku/docker-registry-client/registry"
+)
+
+const (
+	registryURL = "https://registry-1.docker.io/"
+	hubURL      = "https://hub.docker.com/v2/"
+
+	mediaTypeManifestV2   = "application/vnd.docker.distribution.manifest.v2+json"
+	mediaTypeManifestList = "application/vnd.docker.distribution.manifest.list.v2+json"
+)
+
+// RegistryChecker gets the details of the driver images from a container
+// registry.
+type RegistryChecker interface {
+	// Exists reports whether the image has a "latest" tag.
+	Exists(name string) bool
+	// Tags lists the tags of the image.
+	Tags(name string) ([]string, error)
+	// ImageSize returns the compressed size of the image, in bytes.
+	ImageSize(name, tag string) (int64, error)
+	// Architectures returns the platforms the image is available for.
+	Architectures(name, tag string) ([]string, error)
+	// LastPush returns the time the tag was last pushed.
+	LastPush(name, tag string) (time.Time, error)
+	// PullCount returns the number of pulls of the image.
+	PullCount(name string) (int64, error)
+	// Digest returns the content digest of the tag, like "sha256:...".
+	Digest(name, tag string) (string, error)
+}
+
+// NewDockerHub returns a RegistryChecker for the images in Docker Hub.
+// The registry mirrors, like "https://mirror.gcr.io", are tried in order
+// when a request to Docker Hub fails, like when it is throttled.
+func NewDockerHub(mirrors ...string) RegistryChecker {
+	l := &dockerHub{hub: NewHTTPClient(time.Minute)}
+	for _, url := range append([]string{registryURL}, mirrors...) {
+		l.regs = append(l.regs, newRegistry(url))
+	}
+	return l
+}
+
+// newRegistry is like registry.New, but with the transport of the other
+// services and without checking the registry on creation.
+func newRegistry(url string) *registry.Registry {
+	url = strings.TrimSuffix(url, "/")
+	return &registry.Registry{
+		URL:    url,
+		Client: &http.Client{Transport: registry.WrapTransport(newTransport(), url, "", "")},
+		Logf:   registry.Quiet,
+	}
+}
+
+type dockerHub struct {
+	// regs are Docker Hub followed by its mirrors.
+	regs []*registry.Registry
+	hub  *http.Client
+}
+
+// try calls fn with each registry until it succeeds or the image is not
+// found, returning the last error.
+func (l *dockerHub) try(fn func(r *registry.Registry) error) error {
+	var err error
+	for i, r := range l.regs {
+		if err = fn(r); err == nil || err == errNotFound {
+			return err
+		}
+		if i+1 < len(l.regs) {
+			msg := fmt.Sprintf("%s failed, trying %s: %v", r.URL, l.regs[i+1].URL, err)
+			emit(Event{Kind: EventRetry, Source: "docker", Msg: msg})
+			if Verbose {
+				log.Print(msg)
+			}
+		}
+	}
+	return err
+}
+
+// loadImage fills the image-related fields of the driver. Failures to get
+// the optional details are logged and leave the fields empty.
+func loadImage(r RegistryChecker, d *Driver) {
+	name := d.Image
+	if name == "" || !r.Exists(name) {
+		return
+	}
+	d.DockerhubURL = `https://hub.docker.com/r/` + name + `/`
+
+	if tags, err := r.Tags(name); err != nil {
+		enrichFailed("docker", "cannot list tags of %s: %v", name, err)
+	} else {
+		d.LatestVersion = newestVersion(tags)
+	}
+	var err error
+	if d.ImageSize, err = r.ImageSize(name, "latest"); err != nil {
+		enrichFailed("docker", "cannot get image size of %s: %v", name, err)
+	}
+	if t, err := r.LastPush(name, "latest"); err != nil {
+		enrichFailed("docker", "cannot get last push of %s: %v", name, err)
+	} else {
+		d.ImagePushed = &t
+	}
+	if d.Architectures, err = r.Architectures(name, "latest"); err != nil {
+		enrichFailed("docker", "cannot get architectures of %s: %v", name, err)
+	}
+	if d.PullCount, err = r.PullCount(name); err != nil {
+		enrichFailed("docker", "cannot get pull count of %s: %v", name, err)
+	}
+	if d.Digest, err = r.Digest(name, d.RecommendedTag()); err != nil {
+		enrichFailed("docker", "cannot get digest of %s: %v", name, err)
+	}
+}
+
+func (l *dockerHub) Exists(name string) bool {
+	// dockerhub site always returns 200, even if repository does not exists
+	// so we will check image via Docker registry protocol
+	err := l.try(func(r *registry.Registry) error {
+		_, err := r.Manifest(name, "latest")
+		return err
+	})
+	return err == nil
+}
+
+func (l *dockerHub) Tags(name string) (tags []string, err error) {
+	err = l.try(func(r *registry.Registry) error {
+		tags, err = r.Tags(name)
+		return err
+	})
+	return tags, err
+}
+
+// ImageSize returns the compressed size of the image, as the sum of the
+// sizes of its config and layers listed in the v2 manifest.
+func (l *dockerHub) ImageSize(name, tag string) (int64, error) {
+	var m struct {
+		Config struct {
+			Size int64 `json:"size"`
+		} `json:"config"`
+		Layers []struct {
+			Size int64 `json:"size"`
+		} `json:"layers"`
+	}
+	err := l.try(func(r *registry.Registry) error {
+		req, err := http.NewRequest("GET", r.URL+"/v2/"+name+"/manifests/"+tag, nil)
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Accept", mediaTypeManifestV2)
+		return getJSON(r.Client, req, &m)
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	size := m.Config.Size
+	for _, layer := range m.Layers {
+		size += layer.Size
+	}
+	return size, nil
+}
+
+// Architectures returns the platforms the image is available for, like
+// "amd64" or "arm/v7". Images pushed as a manifest list report every
+// platform in the list, while single-platform images report the one set
+// in their config.
+func (l *dockerHub) Architectures(name, tag string) ([]string, error) {
+	type platform struct {
+		Architecture string `json:"architecture"`
+		OS           string `json:"os"`
+		Variant      string `json:"variant"`
+	}
+	var m struct {
+		Manifests []struct {
+			Platform platform `json:"platform"`
+		} `json:"manifests"`
+		Config struct {
+			Digest string `json:"digest"`
+		} `json:"config"`
+	}
+	var plats []platform
+	err := l.try(func(r *registry.Registry) error {
+		req, err := http.NewRequest("GET", r.URL+"/v2/"+name+"/manifests/"+tag, nil)
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Accept", mediaTypeManifestList+", "+mediaTypeManifestV2)
+		if err := getJSON(r.Client, req, &m); err != nil {
+			return err
+		}
+		if len(m.Manifests) != 0 {
+			for _, sub := range m.Manifests {
+				plats = append(plats, sub.Platform)
+			}
+			return nil
+		}
+		req, err = http.NewRequest("GET", r.URL+"/v2/"+name+"/blobs/"+m.Config.Digest, nil)
+		if err != nil {
+			return err
+		}
+		var p platform
+		if err := getJSON(r.Client, req, &p); err != nil {
+			return err
+		}
+		plats = append(plats, p)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	var archs []string
+	for _, p := range plats {
+		// attestation manifests are listed with an "unknown" platform
+		if p.Architecture == "" || p.Architecture == "unknown" {
+			continue
+		}
+		arch := p.Architecture
+		if p.Variant != "" {
+			arch += "/" + p.Variant
+		}
+		if !seen[arch] {
+			seen[arch] = true
+			archs = append(archs, arch)
+		}
+	}
+	sort.Strings(archs)
+	return archs, nil
+}
+
+// LastPush returns the time the tag was last pushed to Docker Hub. The
+// registry protocol does not expose it, so the Hub API is used instead.
+func (l *dockerHub) LastPush(name, tag string) (time.Time, error) {
+	req, err := http.NewRequest("GET", hubURL+"repositories/"+name+"/tags/"+tag+"/", nil)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	var t struct {
+		LastUpdated time.Time `json:"last_updated"`
+	}
+	err = getJSON(l.hub, req, &t)
+	return t.LastUpdated, err
+}
+
+// PullCount returns the number of times the image was pulled from Docker Hub.
+func (l *dockerHub) PullCount(name string) (int64, error) {
+	req, err := http.NewRequest("GET", hubURL+"repositories/"+name+"/", nil)
+	if err != nil {
+		return 0, err
+	}
+
+	var r struct {
+		PullCount int64 `json:"pull_count"`
+	}
+	err = getJSON(l.hub, req, &r)
+	return r.PullCount, err
+}
+
+// Digest returns the digest of the manifest of the tag, that is the one of
+// the manifest list for multi-platform images.
+func (l *dockerHub) Digest(name, tag string) (digest string, err error) {
+	err = l.try(func(r *registry.Registry) error {
+		digest, err = manifestDigest(r, name, tag)
+		return err
+	})
+	return digest, err
+}
+
+func manifestDigest(r *registry.Registry, name, tag string) (string, error) {
+	req, err := http.NewRequest("HEAD", r.URL+"/v2/"+name+"/manifests/"+tag, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Accept", mediaTypeManifestList+", "+mediaTypeManifestV2)
+
+	resp, err := r.Client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return "", errNotFound
+	} else if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%s: unexpected status: %s", req.URL, resp.Status)
+	}
+	d := resp.Header.Get("Docker-Content-Digest")
+	if d == "" {
+		return "", fmt.Errorf("%s: no digest returned", req.URL)
+	}
+	return d, nil
+}
+
+var errNotFound = errors.New("not found")
+
+func getJSON(cli *http.Client, req *http.Request, v interface{}) error {
+	resp, err := cli.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return errNotFound
+	} else if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s: unexpected status: %s", req.URL, resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
@@ -0,0 +1,75 @@
+package languages
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+	"strings"
+
+	"gopkg.in/bblfsh/sdk.v1/manifest"
+)
+
+// editorIndex maps the files opened in an editor to the drivers that parse
+// them, for the editor plugins integrating bblfsh.
+type editorIndex struct {
+	Version int `json:"version"`
+	// Extensions are the language keys by file extension, with the dot.
+	Extensions map[string]string `json:"extensions"`
+	// Languages are the drivers by language key and alias.
+	Languages map[string]editorEntry `json:"languages"`
+}
+
+type editorEntry struct {
+	Language string `json:"language"`
+	// Image is the image reference to install, with the recommended tag.
+	Image     string `json:"image"`
+	UAST      bool   `json:"uast"`
+	Annotated bool   `json:"annotated"`
+}
+
+// WriteEditors writes a compact JSON mapping of file extensions and
+// language identifiers to the driver images, like:
+//
+//	{"version": 1, "extensions": {".go": "go"}, "languages": {"go":
+//	{"language": "go", "image": "bblfsh/go-driver:v2.1.0", "uast": true,
+//	"annotated": true}}}
+//
+// The aliases of the languages are listed along with their keys. Drivers
+// without a published image are not listed. An extension claimed by
+// several drivers maps to the most mature one.
+func WriteEditors(w io.Writer, list []Driver) error {
+	idx := editorIndex{
+		Version:    apiVersion,
+		Extensions: make(map[string]string),
+		Languages:  make(map[string]editorEntry),
+	}
+	// the most mature drivers go first, to claim the extensions
+	list = append([]Driver{}, list...)
+	sort.SliceStable(list, func(i, j int) bool {
+		return list[i].Status.Rank() > list[j].Status.Rank()
+	})
+	for _, d := range list {
+		if d.DockerhubURL == "" {
+			continue
+		}
+		e := editorEntry{
+			Language:  d.Language,
+			Image:     d.Image + ":" + d.RecommendedTag(),
+			UAST:      d.Supports(manifest.UAST),
+			Annotated: d.Supports(manifest.Roles),
+		}
+		for _, id := range append([]string{d.Language}, d.Aliases...) {
+			id = strings.ToLower(id)
+			if _, ok := idx.Languages[id]; !ok {
+				idx.Languages[id] = e
+			}
+		}
+		for _, ext := range d.Extensions {
+			ext = strings.ToLower(ext)
+			if _, ok := idx.Extensions[ext]; !ok {
+				idx.Extensions[ext] = d.Language
+			}
+		}
+	}
+	return json.NewEncoder(w).Encode(idx)
+}
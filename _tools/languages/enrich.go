@@ -0,0 +1,228 @@
+package languages
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Enricher fills some of the details of a driver. Failures are logged and
+// leave the details empty.
+type Enricher interface {
+	// Name identifies the enricher in EnrichOptions.Enrichers.
+	Name() string
+	Enrich(ctx context.Context, d *Driver)
+}
+
+// enricherFunc is an Enricher implemented by a function.
+type enricherFunc struct {
+	name string
+	fn   func(d *Driver)
+}
+
+func (e enricherFunc) Name() string                          { return e.name }
+func (e enricherFunc) Enrich(ctx context.Context, d *Driver) { e.fn(d) }
+
+// preparer is an Enricher that loads the details of all the drivers at
+// once, before each driver is enriched.
+type preparer interface {
+	Prepare(ctx context.Context, list []Driver)
+}
+
+// githubEnricher loads the details of the repositories, batching the ones
+// available in the GraphQL API.
+type githubEnricher struct {
+	g *githubClient
+}
+
+func (githubEnricher) Name() string                            { return "github" }
+func (e githubEnricher) Enrich(ctx context.Context, d *Driver) { e.g.loadRepo(d) }
+
+func (e githubEnricher) Prepare(ctx context.Context, list []Driver) {
+	if err := e.g.prefetch(list); err != nil {
+		// the REST API is used instead
+		enrichFailed("github", "cannot query the repositories in a batch: %v", err)
+	}
+}
+
+// EnrichOptions selects the optional details filled by Enrich.
+type EnrichOptions struct {
+	// Profiles loads the names and avatars of the maintainers.
+	Profiles bool
+	// Coverage loads the test coverage from Codecov or Coveralls.
+	Coverage bool
+	// Benchmark is a directory with a corpus of files for each language,
+	// in a directory named after the language key, to measure the
+	// performance of the driver images. It needs a local Docker daemon.
+	Benchmark string
+	// Samples is a directory with a sample file per language, named after
+	// the language key, to parse with the driver images. It needs a local
+	// Docker daemon.
+	Samples string
+	// SmokeTest parses an empty file with the driver images, to check that
+	// they work. It needs a local Docker daemon.
+	SmokeTest bool
+	// Scan counts the known vulnerabilities of the driver images. It needs
+	// Trivy to be installed.
+	Scan bool
+	// Registry is the registry of the driver images, Docker Hub by
+	// default.
+	Registry RegistryChecker
+	// Extra enrichers run after the ones selected by the other options.
+	Extra []Enricher
+	// Enrichers restricts the enrichers to run to the ones with these
+	// names, if set. See EnricherNames.
+	Enrichers []string
+	// Done is called with each driver as soon as its enrichment finishes,
+	// to stream the results. It may be called from several goroutines at
+	// the same time.
+	Done func(Driver)
+}
+
+// EnricherNames returns the names of the built-in enrichers, in the order
+// they run.
+func EnricherNames() []string {
+	return []string{"docker", "smoke-test", "scan", "github", "profiles", "coverage", "examples"}
+}
+
+// enricherOptions are the options enabling the built-in enrichers that do
+// not always run, by name.
+var enricherOptions = map[string]string{
+	"smoke-test": "SmokeTest",
+	"scan":       "Scan",
+	"profiles":   "Profiles",
+	"coverage":   "Coverage",
+	"examples":   "Samples",
+}
+
+// enrichers returns the enrichers selected in the options, in the order
+// they must run: the ones using the images need the version found by the
+// docker one.
+func (o *EnrichOptions) enrichers() ([]Enricher, error) {
+	reg := o.Registry
+	if reg == nil {
+		reg = NewDockerHub()
+	}
+	gh := newGithub()
+
+	list := []Enricher{
+		enricherFunc{"docker", func(d *Driver) { loadImage(reg, d) }},
+	}
+	if o.SmokeTest {
+		list = append(list, enricherFunc{"smoke-test", smokeTest})
+	}
+	if o.Scan {
+		list = append(list, enricherFunc{"scan", loadVulnerabilities})
+	}
+	list = append(list, githubEnricher{gh})
+	if o.Profiles {
+		list = append(list, enricherFunc{"profiles", newProfiles(gh).loadMaintainers})
+	}
+	if o.Coverage {
+		list = append(list, enricherFunc{"coverage", newCoverage().loadCoverage})
+	}
+	if o.Samples != "" {
+		list = append(list, enricherFunc{"examples", newExamples(o.Samples).loadExample})
+	}
+	list = append(list, o.Extra...)
+
+	if len(o.Enrichers) == 0 {
+		return list, nil
+	}
+	built := make(map[string]bool)
+	for _, e := range list {
+		built[e.Name()] = true
+	}
+	selected := make(map[string]bool, len(o.Enrichers))
+	for _, name := range o.Enrichers {
+		if !built[name] {
+			if opt, ok := enricherOptions[name]; ok {
+				return nil, fmt.Errorf("enricher %s needs EnrichOptions.%s", name, opt)
+			}
+			return nil, fmt.Errorf("unknown enricher %q (available: %s)",
+				name, strings.Join(EnricherNames(), ", "))
+		}
+		selected[name] = true
+	}
+	var out []Enricher
+	for _, e := range list {
+		if selected[e.Name()] {
+			out = append(out, e)
+		}
+	}
+	return out, nil
+}
+
+// Enrich fills the details of the drivers from Docker Hub, GitHub and
+// the services selected in the options. The enrichers run in order for
+// each driver, and several drivers are enriched at the same time. Failures
+// are logged and leave the details empty. If ctx is cancelled, no more
+// lookups are started and its error is returned; the drivers enriched
+// until then are the ones passed to EnrichOptions.Done.
+func Enrich(ctx context.Context, list []Driver, opts *EnrichOptions) error {
+	if opts == nil {
+		opts = &EnrichOptions{}
+	}
+	enrichers, err := opts.enrichers()
+	if err != nil {
+		return err
+	}
+
+	for _, e := range enrichers {
+		if p, ok := e.(preparer); ok {
+			p.Prepare(ctx, list)
+		}
+	}
+
+	var (
+		wg sync.WaitGroup
+		// limits the number of concurrent requests
+		tokens = make(chan struct{}, 3)
+	)
+	for i := range list {
+		wg.Add(1)
+		go func(d *Driver) {
+			defer wg.Done()
+
+			tokens <- struct{}{}
+			defer func() {
+				<-tokens
+			}()
+
+			for _, e := range enrichers {
+				if ctx.Err() != nil {
+					// the driver is left half enriched
+					return
+				}
+				emit(Event{Kind: EventLookupStarted, Driver: d.Language, Source: e.Name()})
+				start := time.Now()
+				e.Enrich(ctx, d)
+				emit(Event{Kind: EventLookupDone, Driver: d.Language, Source: e.Name(), Duration: time.Since(start)})
+			}
+			if opts.Done != nil && opts.Benchmark == "" && ctx.Err() == nil {
+				opts.Done(*d)
+			}
+		}(&list[i])
+	}
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if opts.Benchmark != "" {
+		// one driver at a time, so the measures are comparable
+		b := newBenchmarks(opts.Benchmark)
+		for i := range list {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			b.loadPerformance(&list[i])
+			if opts.Done != nil {
+				opts.Done(list[i])
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package languages
+
+import (
+	"time"
+)
+
+// Kinds of events.
+const (
+	EventLookupStarted = "lookup-started"
+	EventLookupDone    = "lookup-done"
+	EventCacheHit      = "cache-hit"
+	EventCacheMiss     = "cache-miss"
+	EventRetry         = "retry"
+	EventWait          = "wait"
+	EventError         = "error"
+)
+
+// Event is a step of the enrichment of the drivers, for the tools that
+// follow the progress of a run.
+type Event struct {
+	Time time.Time `json:"time"`
+	Kind string    `json:"event"`
+	// Driver is the language of the driver, if the event is about one.
+	Driver string `json:"driver,omitempty"`
+	// Source is the enricher or service of the event, like "github".
+	Source string `json:"source,omitempty"`
+	Msg    string `json:"msg,omitempty"`
+	// Duration is the time of the lookup, on EventLookupDone, or of the
+	// wait for a rate limit, on EventWait.
+	Duration time.Duration `json:"duration,omitempty"`
+}
+
+// Events is called with the events of the enrichment, if set. It may be
+// called from several goroutines at the same time.
+var Events func(Event)
+
+func emit(e Event) {
+	if Events == nil {
+		return
+	}
+	e.Time = time.Now().UTC()
+	Events(e)
+}
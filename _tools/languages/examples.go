@@ -0,0 +1,136 @@
+package languages
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	bblfsh "gopkg.in/bblfsh/client-go.v2"
+)
+
+const (
+	// driverPort is the port the driver images serve the protocol on.
+	driverPort = "9432/tcp"
+	// exampleLines is the number of lines of UAST shown on the pages.
+	exampleLines = 40
+	// exampleRetries is the number of attempts to parse the sample while
+	// the driver starts.
+	exampleRetries = 10
+)
+
+// examples generates UAST examples by parsing a sample file with the
+// published image of each driver. It needs a local Docker daemon.
+type examples struct {
+	// dir holds the sample files, named after the language key.
+	dir string
+}
+
+func newExamples(dir string) *examples {
+	return &examples{dir: dir}
+}
+
+// loadExample parses the sample of the driver language with its latest
+// image, keeping the beginning of the resulting UAST. A failure is logged,
+// as it means that the published image is broken.
+func (e *examples) loadExample(d *Driver) {
+	if d.DockerhubURL == "" {
+		return
+	}
+	paths, err := filepath.Glob(filepath.Join(e.dir, d.Language+".*"))
+	if err != nil || len(paths) == 0 {
+		return
+	}
+	src, err := ioutil.ReadFile(paths[0])
+	if err != nil {
+		enrichFailed("examples", "cannot read sample of %s: %v", d.Language, err)
+		return
+	}
+	uast, err := parseWithImage(d.Image+":"+d.RecommendedTag(), d.Language, filepath.Base(paths[0]), string(src))
+	if err != nil {
+		enrichFailed("examples", "cannot parse sample with %s: %v", d.Image, err)
+		return
+	}
+	d.Sample = string(src)
+	d.UASTExample = truncateLines(uast, exampleLines)
+}
+
+// parseWithImage starts a container of a driver image and returns the
+// UAST of the given source.
+func parseWithImage(image, lang, name, src string) (string, error) {
+	c, err := startDriver(image)
+	if err != nil {
+		return "", err
+	}
+	defer c.stop()
+	return c.waitParse(lang, name, src)
+}
+
+// driverContainer is a running container of a driver image.
+type driverContainer struct {
+	id string
+	// addr is the address the driver is published on.
+	addr string
+}
+
+// startDriver starts a container of a driver image, publishing its port
+// on a random local one.
+func startDriver(image string) (*driverContainer, error) {
+	out, err := exec.Command("docker", "run", "--rm", "-d", "-p", "127.0.0.1::9432", image).Output()
+	if err != nil {
+		return nil, fmt.Errorf("docker run: %v", err)
+	}
+	c := &driverContainer{id: strings.TrimSpace(string(out))}
+
+	out, err = exec.Command("docker", "port", c.id, driverPort).Output()
+	if err != nil {
+		c.stop()
+		return nil, fmt.Errorf("docker port: %v", err)
+	}
+	c.addr = strings.TrimSpace(strings.SplitN(string(out), "\n", 2)[0])
+	return c, nil
+}
+
+// waitParse parses the source, retrying while the driver starts.
+func (c *driverContainer) waitParse(lang, name, src string) (string, error) {
+	for i := 0; ; i++ {
+		uast, err := parseWith(c.addr, lang, name, src)
+		if err == nil || i == exampleRetries {
+			return uast, err
+		}
+		time.Sleep(time.Second)
+	}
+}
+
+func (c *driverContainer) stop() {
+	exec.Command("docker", "rm", "-f", c.id).Run()
+}
+
+// parseWith parses the source with a new client, closed before returning
+// so the retries do not leak connections.
+func parseWith(addr, lang, name, src string) (string, error) {
+	cli, err := bblfsh.NewClient(addr)
+	if err != nil {
+		return "", err
+	}
+	defer cli.Close()
+	res, err := cli.NewParseRequest().Language(lang).Filename(name).Content(src).Do()
+	if err != nil {
+		return "", err
+	}
+	if len(res.Errors) != 0 {
+		return "", fmt.Errorf("%s", strings.Join(res.Errors, "; "))
+	}
+	return res.UAST.String(), nil
+}
+
+// truncateLines keeps the first n lines of s.
+func truncateLines(s string, n int) string {
+	lines := strings.SplitN(s, "\n", n+1)
+	if len(lines) <= n {
+		return s
+	}
+	return strings.Join(lines[:n], "\n") + "\n..."
+}
@@ -0,0 +1,81 @@
+package languages
+
+import (
+	"context"
+	"time"
+)
+
+// StaticSource is a DriverSource with a fixed list of drivers, to render
+// documents from known data without accessing any service.
+type StaticSource []Driver
+
+func (s StaticSource) Drivers(ctx context.Context) ([]Driver, error) {
+	return append([]Driver(nil), s...), nil
+}
+
+// Image is an image of a MemoryRegistry.
+type Image struct {
+	Tags          []string
+	Size          int64
+	Pushed        time.Time
+	Architectures []string
+	Pulls         int64
+	Digest        string
+}
+
+// MemoryRegistry is a RegistryChecker with the images in memory, by name.
+// All the tags of an image share its details.
+type MemoryRegistry map[string]Image
+
+func (r MemoryRegistry) Exists(name string) bool {
+	_, ok := r[name]
+	return ok
+}
+
+func (r MemoryRegistry) Tags(name string) ([]string, error) {
+	img, ok := r[name]
+	if !ok {
+		return nil, errNotFound
+	}
+	return img.Tags, nil
+}
+
+func (r MemoryRegistry) ImageSize(name, tag string) (int64, error) {
+	img, ok := r[name]
+	if !ok {
+		return 0, errNotFound
+	}
+	return img.Size, nil
+}
+
+func (r MemoryRegistry) Architectures(name, tag string) ([]string, error) {
+	img, ok := r[name]
+	if !ok {
+		return nil, errNotFound
+	}
+	return img.Architectures, nil
+}
+
+func (r MemoryRegistry) LastPush(name, tag string) (time.Time, error) {
+	img, ok := r[name]
+	if !ok {
+		return time.Time{}, errNotFound
+	}
+	return img.Pushed, nil
+}
+
+func (r MemoryRegistry) PullCount(name string) (int64, error) {
+	img, ok := r[name]
+	if !ok {
+		return 0, errNotFound
+	}
+	return img.Pulls, nil
+}
+
+func (r MemoryRegistry) Digest(name, tag string) (string, error) {
+	img, ok := r[name]
+	if !ok {
+		return "", errNotFound
+	}
+	return img.Digest, nil
+}
@@ -0,0 +1,122 @@
+package languages
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// RegistrySource lists the official drivers from the <org>/*-driver images
+// in Docker Hub. It is a fallback for OfficialSource while the discovery
+// in GitHub is not available, as only the language and image of the
+// drivers are known.
+type RegistrySource struct{}
+
+func (RegistrySource) Drivers(ctx context.Context) ([]Driver, error) {
+	repos, err := hubDriverRepos(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var list []Driver
+	for _, r := range repos {
+		d := Driver{
+			Source:    sourceGithub,
+			GithubURL: "https://github.com/" + org + "/" + r.Name,
+			Image:     org + "/" + r.Name,
+		}
+		d.Language = strings.TrimSuffix(r.Name, "-driver")
+		list = append(list, d)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Language < list[j].Language })
+	log.Println(len(list), "driver images found in Docker Hub")
+	return list, nil
+}
+
+// hubRepo is a repository of the organization in Docker Hub.
+type hubRepo struct {
+	Name        string     `json:"name"`
+	LastUpdated *time.Time `json:"last_updated"`
+}
+
+// hubDriverRepos lists the <org>/*-driver repositories in Docker Hub.
+func hubDriverRepos(ctx context.Context) ([]hubRepo, error) {
+	cli := NewHTTPClient(time.Minute)
+	var repos []hubRepo
+	// Docker Hub does not serve the catalog of the registry, but lists the
+	// repositories of an organization, in pages
+	next := hubURL + "repositories/" + org + "/?page_size=100"
+	for next != "" {
+		req, err := http.NewRequest("GET", next, nil)
+		if err != nil {
+			return nil, err
+		}
+		var page struct {
+			Next    string    `json:"next"`
+			Results []hubRepo `json:"results"`
+		}
+		if err := getJSON(cli, req.WithContext(ctx), &page); err != nil {
+			return nil, err
+		}
+		for _, r := range page.Results {
+			if strings.HasSuffix(r.Name, "-driver") {
+				repos = append(repos, r)
+			}
+		}
+		next = page.Next
+	}
+	return repos, nil
+}
+
+// CacheSource lists the official drivers of a JSON output of a previous
+// run. It is a fallback for OfficialSource while the discovery in GitHub
+// is not available.
+type CacheSource struct {
+	Path string
+}
+
+func (s CacheSource) Drivers(ctx context.Context) ([]Driver, error) {
+	all, err := ReadDrivers(s.Path)
+	if err != nil {
+		return nil, err
+	}
+	var list []Driver
+	for _, d := range all {
+		if !d.Community && d.Source == sourceGithub {
+			list = append(list, d)
+		}
+	}
+	log.Println(len(list), "drivers found in", s.Path)
+	return list, nil
+}
+
+// fallback lists the drivers of the fallback source after the one of
+// the primary source fails, reporting why the list is degraded and setting
+// it as the Degraded reason of the drivers.
+func fallback(ctx context.Context, src DriverSource, cause error, degraded func(string)) ([]Driver, error) {
+	log.Println("cannot discover the drivers in GitHub, using a fallback:", cause)
+	list, err := src.Drivers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%v; fallback failed: %v", cause, err)
+	}
+	var what string
+	switch s := src.(type) {
+	case RegistrySource:
+		what = "the images in Docker Hub"
+	case CacheSource:
+		what = "a previous run (" + s.Path + ")"
+	default:
+		what = "a fallback"
+	}
+	reason := fmt.Sprintf("the discovery of the drivers in GitHub failed, so the official drivers are listed from %s and may be incomplete or out of date", what)
+	for i := range list {
+		list[i].Degraded = reason
+	}
+	if degraded != nil {
+		degraded(reason)
+	}
+	return list, nil
+}
@@ -0,0 +1,81 @@
+package languages
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"time"
+)
+
+const (
+	feedTitle = "Babelfish drivers"
+	feedID    = "https://doc.bblf.sh/languages.html"
+	// feedEntries is the number of entries kept in the feed.
+	feedEntries = 50
+)
+
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	Title   string      `xml:"title"`
+	ID      string      `xml:"id"`
+	Link    atomLink    `xml:"link"`
+	Updated string      `xml:"updated"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+}
+
+type atomEntry struct {
+	Title   string   `xml:"title"`
+	ID      string   `xml:"id"`
+	Link    atomLink `xml:"link"`
+	Updated string   `xml:"updated"`
+	Summary string   `xml:"summary"`
+}
+
+// UpdateFeed adds an entry for each change to the Atom feed at path,
+// creating it if it does not exist. The feed is not modified if there
+// are no changes.
+func UpdateFeed(path string, changes []Change, now time.Time) error {
+	if len(changes) == 0 {
+		return nil
+	}
+	feed := atomFeed{Title: feedTitle, ID: feedID, Link: atomLink{Href: feedID}}
+	data, err := ioutil.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	} else if err == nil {
+		if err := xml.Unmarshal(data, &feed); err != nil {
+			return err
+		}
+	}
+
+	updated := now.UTC().Format(time.RFC3339)
+	entries := make([]atomEntry, 0, len(changes)+len(feed.Entries))
+	for _, c := range changes {
+		entries = append(entries, atomEntry{
+			Title: c.Summary,
+			ID: fmt.Sprintf("tag:bblf.sh,%s:%s/%s/%d",
+				now.UTC().Format("2006-01-02"), c.Language, c.Kind, now.Unix()),
+			Link:    atomLink{Href: "https://doc.bblf.sh/languages/" + c.Language + ".html"},
+			Updated: updated,
+			Summary: c.Summary,
+		})
+	}
+	entries = append(entries, feed.Entries...)
+	if len(entries) > feedEntries {
+		entries = entries[:feedEntries]
+	}
+	feed.Entries = entries
+	feed.Updated = updated
+
+	data, err = xml.MarshalIndent(feed, "", "\t")
+	if err != nil {
+		return err
+	}
+	data = append([]byte(xml.Header), data...)
+	return ioutil.WriteFile(path, append(data, '\n'), 0644)
+}
@@ -0,0 +1,294 @@
+package languages
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"strings"
+)
+
+// Renderer renders the generated documents in a specific markup language.
+type Renderer interface {
+	// Header and Footer return the content surrounding the generated sections.
+	Header() string
+	Footer() string
+	// Legend returns the legend of the language tables.
+	Legend() string
+	// Heading writes a section heading.
+	Heading(w io.Writer, title string)
+	// Subheading writes the heading of a subsection.
+	Subheading(w io.Writer, title string)
+	// Paragraph writes a paragraph of text already rendered with this renderer.
+	Paragraph(w io.Writer, text string)
+	// Code writes a block of code in the given language.
+	Code(w io.Writer, lang, code string)
+	// Tasks writes a list of open tasks, already rendered with this
+	// renderer.
+	Tasks(w io.Writer, items []string)
+	// Table writes a table. The header is plain text, while the cells are
+	// expected to be already rendered with this renderer.
+	Table(w io.Writer, header []string, rows [][]string)
+
+	// Text escapes plain text.
+	Text(s string) string
+	// Link returns a link with the given text. An empty url renders the
+	// text alone.
+	Link(text, url string) string
+	// List returns a list of items already rendered with this renderer.
+	List(items []string) string
+	// Image returns an inline image, if the markup language supports it.
+	Image(src, alt string) string
+	// Badge returns a short text highlighted with the given color, if the
+	// markup language supports it.
+	Badge(text, color string) string
+	// Mark returns a yes or no mark, like ✓ or ✗, linked to url if it is
+	// not empty.
+	Mark(v bool, url string) string
+}
+
+// Badge colors, matching the ones used by shields.io.
+const (
+	colorGreen  = "#4c1"
+	colorYellow = "#dfb317"
+	colorRed    = "#e05d44"
+)
+
+// Renderers are the supported renderers, by the name of their format.
+var Renderers = map[string]Renderer{
+	"md":    Markdown{},
+	"html":  HTML{PlainSymbols: true},
+	"table": Terminal{},
+}
+
+// SetPlainSymbols sets whether the renderer of the format renders the yes
+// and no marks as text, for screen readers, instead of ✓ and ✗.
+func SetPlainSymbols(format string, plain bool) error {
+	switch f := Renderers[format].(type) {
+	case Markdown:
+		f.PlainSymbols = plain
+		Renderers[format] = f
+	case HTML:
+		f.PlainSymbols = plain
+		Renderers[format] = f
+	case Terminal:
+		f.PlainSymbols = plain
+		Renderers[format] = f
+	default:
+		return fmt.Errorf("unknown format %q", format)
+	}
+	return nil
+}
+
+// markText returns the text of a yes or no mark.
+func markText(v, plain bool) string {
+	if !plain {
+		return boolIcon(v)
+	}
+	if v {
+		return "yes"
+	}
+	return "no"
+}
+
+// Markdown renders GitHub flavored markdown, as used by GitBook.
+type Markdown struct {
+	// PlainSymbols renders the marks as "yes" and "no".
+	PlainSymbols bool
+}
+
+var mdEscaper = strings.NewReplacer(`\`, `\\`, `*`, `\*`, `_`, `\_`, `|`, `\|`)
+
+func (Markdown) Header() string { return header }
+func (Markdown) Footer() string { return "" }
+func (Markdown) Legend() string { return legend }
+
+func (Markdown) Heading(w io.Writer, title string) {
+	fmt.Fprintf(w, "\n# %s\n", title)
+}
+
+func (Markdown) Subheading(w io.Writer, title string) {
+	fmt.Fprintf(w, "\n## %s\n", title)
+}
+
+func (Markdown) Paragraph(w io.Writer, text string) {
+	fmt.Fprintf(w, "\n%s\n", text)
+}
+
+func (Markdown) Code(w io.Writer, lang, code string) {
+	fmt.Fprintf(w, "\n```%s\n%s\n```\n", lang, strings.TrimSuffix(code, "\n"))
+}
+
+func (Markdown) Tasks(w io.Writer, items []string) {
+	fmt.Fprintln(w)
+	for _, it := range items {
+		fmt.Fprintf(w, "- [ ] %s\n", it)
+	}
+}
+
+func (f Markdown) Table(w io.Writer, header []string, rows [][]string) {
+	var head, sep []string
+	for _, h := range header {
+		h = f.Text(h)
+		head = append(head, h)
+		n := len(h)
+		if n < 3 {
+			n = 3
+		}
+		sep = append(sep, strings.Repeat("-", n))
+	}
+	fmt.Fprint(w, "\n| "+strings.Join(head, " | ")+" |\n| "+strings.Join(sep, " | ")+" |\n")
+	for _, row := range rows {
+		fmt.Fprint(w, "| "+strings.Join(row, " | ")+" |\n")
+	}
+}
+
+func (Markdown) Text(s string) string { return mdEscaper.Replace(s) }
+
+func (f Markdown) Link(text, url string) string {
+	if url == "" {
+		return f.Text(text)
+	}
+	return fmt.Sprintf(`[%s](%s)`, f.Text(text), url)
+}
+
+func (Markdown) List(items []string) string { return strings.Join(items, ", ") }
+
+func (Markdown) Image(src, alt string) string { return "" }
+
+func (f Markdown) Badge(text, color string) string { return f.Text(text) }
+
+func (f Markdown) Mark(v bool, url string) string { return f.Link(markText(v, f.PlainSymbols), url) }
+
+// HTML renders standalone HTML documents.
+type HTML struct {
+	// PlainSymbols labels the marks for screen readers, that otherwise
+	// read the symbols themselves.
+	PlainSymbols bool
+}
+
+func (HTML) Header() string { return htmlHeader }
+func (HTML) Footer() string { return htmlFooter }
+func (HTML) Legend() string { return htmlLegend }
+
+func (HTML) Heading(w io.Writer, title string) {
+	fmt.Fprintf(w, "<h1>%s</h1>\n", html.EscapeString(title))
+}
+
+func (HTML) Subheading(w io.Writer, title string) {
+	fmt.Fprintf(w, "<h2>%s</h2>\n", html.EscapeString(title))
+}
+
+func (HTML) Paragraph(w io.Writer, text string) {
+	fmt.Fprintf(w, "<p>%s</p>\n", text)
+}
+
+func (HTML) Code(w io.Writer, lang, code string) {
+	class := ""
+	if lang != "" {
+		class = ` class="language-` + html.EscapeString(lang) + `"`
+	}
+	fmt.Fprintf(w, "<pre><code%s>%s</code></pre>\n",
+		class, html.EscapeString(strings.TrimSuffix(code, "\n")))
+}
+
+func (HTML) Tasks(w io.Writer, items []string) {
+	fmt.Fprint(w, "<ul class=\"tasks\">\n")
+	for _, it := range items {
+		fmt.Fprintf(w, "<li><input type=\"checkbox\" disabled> %s</li>\n", it)
+	}
+	fmt.Fprint(w, "</ul>\n")
+}
+
+func (HTML) Table(w io.Writer, header []string, rows [][]string) {
+	fmt.Fprint(w, "<table>\n<thead>\n<tr>")
+	for _, h := range header {
+		fmt.Fprintf(w, "<th>%s</th>", html.EscapeString(h))
+	}
+	fmt.Fprint(w, "</tr>\n</thead>\n<tbody>\n")
+	for _, row := range rows {
+		fmt.Fprint(w, "<tr>")
+		for _, c := range row {
+			fmt.Fprintf(w, "<td>%s</td>", c)
+		}
+		fmt.Fprint(w, "</tr>\n")
+	}
+	fmt.Fprint(w, "</tbody>\n</table>\n")
+}
+
+func (HTML) Text(s string) string { return html.EscapeString(s) }
+
+func (f HTML) Link(text, url string) string {
+	if url == "" {
+		return f.Text(text)
+	}
+	return fmt.Sprintf(`<a href="%s">%s</a>`, html.EscapeString(url), f.Text(text))
+}
+
+func (HTML) List(items []string) string {
+	if len(items) <= 1 {
+		return strings.Join(items, "")
+	}
+	return "<ul><li>" + strings.Join(items, "</li><li>") + "</li></ul>"
+}
+
+func (HTML) Image(src, alt string) string {
+	return fmt.Sprintf(`<img class="avatar" src="%s" alt="%s">`, html.EscapeString(src), html.EscapeString(alt))
+}
+
+func (f HTML) Badge(text, color string) string {
+	return fmt.Sprintf(`<span class="badge" style="background-color: %s">%s</span>`,
+		html.EscapeString(color), f.Text(text))
+}
+
+func (f HTML) Mark(v bool, url string) string {
+	if !f.PlainSymbols {
+		return f.Link(boolIcon(v), url)
+	}
+	label := markText(v, true)
+	mark := fmt.Sprintf(`<span role="img" aria-label="%s" title="%s">%s</span>`, label, label, boolIcon(v))
+	if url == "" {
+		return mark
+	}
+	return fmt.Sprintf(`<a href="%s">%s</a>`, html.EscapeString(url), mark)
+}
+
+const header = `<!-- Code generated by 'make languages' DO NOT EDIT. -->
+`
+
+const legend = `
+- \* The driver is able to return the native AST
+- \*\* The driver is able to return the UAST
+- \*\*\* The driver is able to return the UAST annotated
+
+
+**Don't see your favorite language? [Help us!](community.md)**
+`
+
+const htmlHeader = `<!DOCTYPE html>
+<!-- Code generated by 'make languages' DO NOT EDIT. -->
+<html>
+<head>
+<meta charset="utf-8">
+<title>Babelfish supported languages</title>
+<style>
+table { border-collapse: collapse; }
+th, td { border: 1px solid #ddd; padding: 4px 8px; }
+.badge { color: #fff; border-radius: 3px; padding: 1px 6px; }
+.avatar { width: 20px; height: 20px; border-radius: 50%; vertical-align: middle; margin-right: 4px; }
+td ul { margin: 0; padding-left: 0; list-style: none; }
+</style>
+</head>
+<body>
+`
+
+const htmlLegend = `<ul>
+<li>* The driver is able to return the native AST</li>
+<li>** The driver is able to return the UAST</li>
+<li>*** The driver is able to return the UAST annotated</li>
+</ul>
+<p><strong>Don't see your favorite language? <a href="https://doc.bblf.sh/community.html">Help us!</a></strong></p>
+`
+
+const htmlFooter = `</body>
+</html>
+`
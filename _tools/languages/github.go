@@ -0,0 +1,323 @@
+package languages
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const githubAPI = "https://api.github.com/"
+
+var (
+	githubOnce   sync.Once
+	sharedGithub *githubClient
+)
+
+// newGithub returns the GitHub client shared by all the requests, so they
+// are accounted in the same quota.
+func newGithub() *githubClient {
+	githubOnce.Do(func() {
+		sharedGithub = &githubClient{cli: &http.Client{
+			Timeout:   time.Minute,
+			Transport: newGithubTransport(),
+		}}
+	})
+	return sharedGithub
+}
+
+// githubClient queries the GitHub REST API for driver repositories.
+type githubClient struct {
+	cli *http.Client
+
+	mu sync.Mutex
+	// repos are the repositories loaded by prefetch, by lowercase path
+	repos map[string]*graphqlRepo
+}
+
+func (g *githubClient) get(path string, v interface{}) error {
+	req, err := http.NewRequest("GET", githubAPI+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+	return getJSON(g.cli, req, v)
+}
+
+// repoPath returns the "owner/name" path of the driver repository.
+func repoPath(d *Driver) string {
+	return strings.TrimPrefix(d.GithubURL, "https://github.com/")
+}
+
+// splitRepo splits an "owner/name" repository path.
+func splitRepo(repo string) (owner, name string) {
+	i := strings.Index(repo, "/")
+	if i < 0 {
+		return repo, ""
+	}
+	return repo[:i], repo[i+1:]
+}
+
+// loadRepo fills the fields of the driver that come from its GitHub
+// repository. Failures are logged and leave the fields empty. The details
+// loaded by prefetch are not requested again.
+func (g *githubClient) loadRepo(d *Driver) {
+	if d.GithubURL == "" {
+		return
+	}
+	repo := repoPath(d)
+	batched := g.prefetched(repo)
+	var (
+		branch string
+		err    error
+	)
+	if batched != nil {
+		branch = setPrefetched(batched, d)
+	} else {
+		branch, err = g.loadInfo(repo, d)
+	}
+	if err != nil {
+		enrichFailed("github", "cannot get repository info of %s: %v", repo, err)
+	} else {
+		// the repository may have been renamed
+		repo = repoPath(d)
+		if batched == nil {
+			if err := g.loadLastCommit(repo, branch, d); err != nil {
+				enrichFailed("github", "cannot get last commit of %s: %v", repo, err)
+			}
+			if err := g.loadPullRequests(repo, d); err != nil {
+				enrichFailed("github", "cannot list pull requests of %s: %v", repo, err)
+			}
+		}
+		if err := g.loadBuildStatus(repo, branch, d); err != nil {
+			enrichFailed("github", "cannot get build status of %s: %v", repo, err)
+		}
+		// the details from the files follow the pinned manifest
+		ref := branch
+		if d.Commit != "" {
+			ref = d.Commit
+		}
+		if err := g.loadSDKVersion(repo, ref, d); err != nil {
+			enrichFailed("github", "cannot get SDK version of %s: %v", repo, err)
+		}
+		if err := g.loadManifestLists(repo, ref, d); err != nil {
+			enrichFailed("github", "cannot get manifest of %s: %v", repo, err)
+		}
+		if err := g.loadFixtures(repo, ref, d); err != nil {
+			enrichFailed("github", "cannot list fixtures of %s: %v", repo, err)
+		}
+	}
+	if batched != nil {
+		return
+	}
+	if err := g.loadRelease(repo, d); err != nil {
+		enrichFailed("github", "cannot get latest release of %s: %v", repo, err)
+	}
+}
+
+// archivedNote is the deprecation note of the drivers with an archived
+// repository.
+const archivedNote = "The repository of the driver is archived and it is no longer maintained."
+
+// loadInfo sets the fields that come from the repository itself, and
+// returns the name of its default branch. The requests to a renamed
+// repository are redirected to the new one by GitHub, and the URL of the
+// driver is updated to it.
+func (g *githubClient) loadInfo(repo string, d *Driver) (string, error) {
+	var r struct {
+		FullName      string `json:"full_name"`
+		HTMLURL       string `json:"html_url"`
+		Archived      bool   `json:"archived"`
+		DefaultBranch string `json:"default_branch"`
+		Stars         int    `json:"stargazers_count"`
+		Forks         int    `json:"forks_count"`
+		OpenIssues    int    `json:"open_issues_count"`
+		License       *struct {
+			SPDX string `json:"spdx_id"`
+			Name string `json:"name"`
+		} `json:"license"`
+	}
+	if err := g.get("repos/"+repo, &r); err != nil {
+		return "", err
+	}
+	if r.FullName != "" && !strings.EqualFold(r.FullName, repo) {
+		log.Printf("%s: repository renamed to %s", repo, r.FullName)
+		d.GithubURL = r.HTMLURL
+	}
+	if d.Archived = r.Archived; d.Archived && d.Deprecated == "" {
+		d.Deprecated = archivedNote
+	}
+	d.Stars, d.Forks = r.Stars, r.Forks
+	// includes pull requests, which are subtracted by loadPullRequests
+	d.OpenIssues = r.OpenIssues
+	if l := r.License; l != nil {
+		// licenses not recognized by GitHub have no SPDX identifier
+		if d.License = l.SPDX; d.License == "" || d.License == "NOASSERTION" {
+			d.License = l.Name
+		}
+	}
+	return r.DefaultBranch, nil
+}
+
+// fixturesPath is the directory of the parser test fixtures of the drivers.
+const fixturesPath = "fixtures"
+
+// fixtureOutputs are the extensions of the files generated from each
+// fixture source.
+var fixtureOutputs = []string{".native", ".uast", ".legacy"}
+
+// loadFixtures sets the number of source files in the fixtures directory
+// of the driver, if it has one, and the links to the native AST and UAST
+// of the first fixture that has both.
+func (g *githubClient) loadFixtures(repo, branch string, d *Driver) error {
+	var files []struct {
+		Name string `json:"name"`
+		Type string `json:"type"`
+		URL  string `json:"html_url"`
+	}
+	err := g.get("repos/"+repo+"/contents/"+fixturesPath+"?ref="+branch, &files)
+	if err == errNotFound {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	urls := make(map[string]string, len(files))
+	var sources []string
+files:
+	for _, f := range files {
+		if f.Type != "file" {
+			continue
+		}
+		urls[f.Name] = f.URL
+		for _, ext := range fixtureOutputs {
+			if strings.HasSuffix(f.Name, ext) {
+				continue files
+			}
+		}
+		sources = append(sources, f.Name)
+	}
+	d.Fixtures = len(sources)
+	for _, name := range sources {
+		native, uast := urls[name+".native"], urls[name+".uast"]
+		if native != "" && uast != "" {
+			d.NativeExampleURL, d.UASTExampleURL = native, uast
+			break
+		}
+	}
+	return nil
+}
+
+// loadLastCommit sets the date of the last commit on the given branch.
+func (g *githubClient) loadLastCommit(repo, branch string, d *Driver) error {
+	var c struct {
+		Commit struct {
+			Committer struct {
+				Date time.Time `json:"date"`
+			} `json:"committer"`
+		} `json:"commit"`
+	}
+	if err := g.get("repos/"+repo+"/commits/"+branch, &c); err != nil {
+		return err
+	}
+	d.LastCommit = &c.Commit.Committer.Date
+	return nil
+}
+
+// loadPullRequests sets the number of open pull requests. GitHub counts
+// them as issues too, so they are subtracted from the open issues.
+func (g *githubClient) loadPullRequests(repo string, d *Driver) error {
+	const perPage = 100
+	n := 0
+	for page := 1; ; page++ {
+		var pulls []struct{}
+		err := g.get(fmt.Sprintf("repos/%s/pulls?state=open&per_page=%d&page=%d", repo, perPage, page), &pulls)
+		if err != nil {
+			return err
+		}
+		n += len(pulls)
+		if len(pulls) < perPage {
+			break
+		}
+	}
+	d.OpenPullRequests = n
+	d.OpenIssues -= n
+	return nil
+}
+
+// Build statuses reported for the default branch of the drivers.
+const (
+	buildPassing = "passing"
+	buildFailing = "failing"
+	buildPending = "pending"
+)
+
+// loadBuildStatus sets the CI status of the head of the given branch. CI
+// services like Travis report commit statuses, while GitHub Actions
+// reports check runs, so both are taken into account.
+func (g *githubClient) loadBuildStatus(repo, branch string, d *Driver) error {
+	var st struct {
+		State      string `json:"state"`
+		TotalCount int    `json:"total_count"`
+	}
+	if err := g.get("repos/"+repo+"/commits/"+branch+"/status", &st); err != nil {
+		return err
+	}
+	var states []string
+	if st.TotalCount != 0 {
+		states = append(states, st.State)
+	}
+
+	var checks struct {
+		CheckRuns []struct {
+			Status     string `json:"status"`
+			Conclusion string `json:"conclusion"`
+		} `json:"check_runs"`
+	}
+	if err := g.get("repos/"+repo+"/commits/"+branch+"/check-runs", &checks); err != nil {
+		return err
+	}
+	for _, c := range checks.CheckRuns {
+		if c.Status != "completed" {
+			states = append(states, "pending")
+		} else {
+			states = append(states, c.Conclusion)
+		}
+	}
+
+	d.BuildStatus = ""
+	for _, s := range states {
+		switch s {
+		case "success", "neutral", "skipped":
+			if d.BuildStatus == "" {
+				d.BuildStatus = buildPassing
+			}
+		case "pending":
+			if d.BuildStatus != buildFailing {
+				d.BuildStatus = buildPending
+			}
+		default:
+			d.BuildStatus = buildFailing
+		}
+	}
+	return nil
+}
+
+// loadRelease sets the tag and date of the latest release of the driver.
+// Drivers without releases are not considered an error.
+func (g *githubClient) loadRelease(repo string, d *Driver) error {
+	var rel struct {
+		TagName     string    `json:"tag_name"`
+		PublishedAt time.Time `json:"published_at"`
+	}
+	err := g.get("repos/"+repo+"/releases/latest", &rel)
+	if err == errNotFound {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	d.LatestRelease = rel.TagName
+	d.ReleaseDate = &rel.PublishedAt
+	return nil
+}
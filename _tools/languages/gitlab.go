@@ -0,0 +1,161 @@
+package languages
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"gopkg.in/bblfsh/sdk.v1/manifest/discovery"
+)
+
+// Sources of the discovered drivers.
+const (
+	sourceGithub = "github"
+	sourceGitlab = "gitlab"
+)
+
+func newGitlab(baseURL, token string) *gitlabClient {
+	return &gitlabClient{
+		api:   strings.TrimSuffix(baseURL, "/") + "/api/v4/",
+		token: token,
+		cli:   NewHTTPClient(time.Minute),
+	}
+}
+
+// gitlabClient discovers drivers hosted in a GitLab group.
+type gitlabClient struct {
+	api   string
+	token string
+	cli   *http.Client
+}
+
+func (g *gitlabClient) request(path string) (*http.Request, error) {
+	req, err := http.NewRequest("GET", g.api+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	if g.token != "" {
+		req.Header.Set("PRIVATE-TOKEN", g.token)
+	}
+	return req, nil
+}
+
+type gitlabProject struct {
+	ID            int    `json:"id"`
+	Path          string `json:"path"`
+	WebURL        string `json:"web_url"`
+	DefaultBranch string `json:"default_branch"`
+	Archived      bool   `json:"archived"`
+}
+
+// Drivers returns the drivers in the group and its subgroups. As with the
+// GitHub discovery, driver repositories are the ones named "*-driver" that
+// have a manifest. Drivers with an invalid manifest are reported and
+// skipped.
+func (g *gitlabClient) Drivers(group string, report func(Problem)) ([]Driver, error) {
+	const perPage = 100
+	var projects []gitlabProject
+	for page := 1; ; page++ {
+		req, err := g.request(fmt.Sprintf("groups/%s/projects?include_subgroups=true&per_page=%d&page=%d",
+			url.PathEscape(group), perPage, page))
+		if err != nil {
+			return nil, err
+		}
+		var list []gitlabProject
+		if err := getJSON(g.cli, req, &list); err != nil {
+			return nil, err
+		}
+		projects = append(projects, list...)
+		if len(list) < perPage {
+			break
+		}
+	}
+
+	var out []Driver
+	for _, p := range projects {
+		if !strings.HasSuffix(p.Path, "-driver") || p.Archived {
+			continue
+		}
+		d, err := g.driver(p)
+		if err == errNotFound {
+			log.Printf("skipping %s: no manifest", p.WebURL)
+			continue
+		} else if prob, ok := err.(Problem); ok {
+			report(prob)
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("%s: %v", p.WebURL, err)
+		}
+		out = append(out, d)
+	}
+	return out, nil
+}
+
+func (g *gitlabClient) driver(p gitlabProject) (Driver, error) {
+	d := Driver{Source: sourceGitlab, GitlabURL: p.WebURL}
+
+	data, err := g.rawFile(p, "manifest.toml")
+	if err != nil {
+		return d, err
+	}
+	if err := d.Manifest.Decode(bytes.NewReader(data)); err != nil {
+		return d, Problem{Msg: fmt.Sprintf("%s: invalid manifest: %v", p.WebURL, err)}
+	}
+	setManifestLists(&d, data)
+
+	data, err = g.rawFile(p, "MAINTAINERS")
+	if err != nil && err != errNotFound {
+		return d, err
+	}
+	d.Maintainers = newMaintainers(parseMaintainers(data))
+	return d, nil
+}
+
+func (g *gitlabClient) rawFile(p gitlabProject, path string) ([]byte, error) {
+	req, err := g.request(fmt.Sprintf("projects/%d/repository/files/%s/raw?ref=%s",
+		p.ID, url.PathEscape(path), url.QueryEscape(p.DefaultBranch)))
+	if err != nil {
+		return nil, err
+	}
+	resp, err := g.cli.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, errNotFound
+	} else if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: unexpected status: %s", req.URL, resp.Status)
+	}
+	var buf bytes.Buffer
+	_, err = buf.ReadFrom(resp.Body)
+	return buf.Bytes(), err
+}
+
+// maintainerLine matches the lines of a MAINTAINERS file, in the
+// "Name <email> (@github)" form used by the drivers.
+var maintainerLine = regexp.MustCompile(`^\s*([^<(]+?)\s*(?:<([^>]+)>)?\s*(?:\(@([^)]+)\))?\s*$`)
+
+func parseMaintainers(data []byte) []discovery.Maintainer {
+	var out []discovery.Maintainer
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	for sc.Scan() {
+		line := sc.Text()
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		m := maintainerLine.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		out = append(out, discovery.Maintainer{Name: m[1], Email: m[2], Github: m[3]})
+	}
+	return out
+}
@@ -0,0 +1,100 @@
+package languages
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gopkg.in/bblfsh/sdk.v1/manifest"
+)
+
+var update = flag.Bool("update", false, "update the golden files in testdata")
+
+// goldenDrivers discovers and enriches a fixed list of drivers with the
+// fakes, without accessing any service.
+func goldenDrivers(t *testing.T) []Driver {
+	var goDriver, python, cpp Driver
+	goDriver.Language, goDriver.Name, goDriver.Status = "go", "Go", manifest.Beta
+	goDriver.Features = []manifest.Feature{manifest.AST, manifest.UAST, manifest.Roles}
+	goDriver.GithubURL = "https://github.com/bblfsh/go-driver"
+	goDriver.Image = "bblfsh/go-driver"
+	python.Language, python.Name, python.Status = "python", "Python", manifest.Stable
+	python.Features = []manifest.Feature{manifest.AST, manifest.UAST}
+	python.Runtime.NativeVersion = manifest.Versions{"3.6", "3.7"}
+	python.GithubURL = "https://github.com/bblfsh/python-driver"
+	python.Image = "bblfsh/python-driver"
+	cpp.Language, cpp.Name, cpp.Status = "cpp", "C++", manifest.Planning
+	cpp.GithubURL = "https://github.com/bblfsh/cpp-driver"
+	cpp.Image = "bblfsh/cpp-driver"
+
+	ctx := context.Background()
+	list, err := Discover(ctx, &DiscoverOptions{
+		Sources: []DriverSource{StaticSource{cpp, goDriver, python}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	pushed := time.Date(2019, 3, 1, 12, 0, 0, 0, time.UTC)
+	err = Enrich(ctx, list, &EnrichOptions{
+		Enrichers: []string{"docker"},
+		Registry: MemoryRegistry{
+			"bblfsh/go-driver": {
+				Tags:          []string{"latest", "v2.1.0", "v2.0.0"},
+				Size:          120 << 20,
+				Pushed:        pushed,
+				Architectures: []string{"amd64"},
+				Pulls:         5000,
+				Digest:        "sha256:0123",
+			},
+			"bblfsh/python-driver": {
+				Tags:   []string{"latest", "v2.9.1"},
+				Size:   300 << 20,
+				Pushed: pushed,
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return list
+}
+
+// checkGolden compares the output with the golden file, or updates it with
+// -update.
+func checkGolden(t *testing.T, name string, out []byte) {
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := ioutil.WriteFile(path, out, 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	exp, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(exp, out) {
+		t.Errorf("%s differs, run the tests with -update if the change is expected:\n%s", path, out)
+	}
+}
+
+func TestGoldenMarkdown(t *testing.T) {
+	var buf bytes.Buffer
+	WriteDocument(&buf, Markdown{}, goldenDrivers(t), &DocumentOptions{})
+	checkGolden(t, "languages.md.golden", buf.Bytes())
+}
+
+func TestGoldenJSON(t *testing.T) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetIndent("", "\t")
+	if err := enc.Encode(goldenDrivers(t)); err != nil {
+		t.Fatal(err)
+	}
+	checkGolden(t, "languages.json.golden", buf.Bytes())
+}
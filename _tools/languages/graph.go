@@ -0,0 +1,139 @@
+package languages
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// ecosystem is the graph of the drivers, grouped by status, with edges to
+// their maintainers and to the versions of the SDK they are built with.
+type ecosystem struct {
+	groups      []graphGroup
+	maintainers []graphNode
+	sdks        []graphNode
+	// edges to the maintainers, and to the SDK versions
+	maintained [][2]string
+	built      [][2]string
+}
+
+type graphGroup struct {
+	id, label string
+	drivers   []graphNode
+}
+
+type graphNode struct {
+	id, label string
+}
+
+var nodeUnsafe = regexp.MustCompile(`[^A-Za-z0-9_]`)
+
+// nodeID returns an identifier valid in both Mermaid and DOT.
+func nodeID(prefix, s string) string {
+	return prefix + "_" + nodeUnsafe.ReplaceAllString(s, "_")
+}
+
+func newEcosystem(list []Driver) *ecosystem {
+	g := &ecosystem{}
+	groups := make(map[string]int)
+	seen := make(map[string]bool)
+	for _, d := range list {
+		title := statusTitle(d.Status)
+		if d.Community {
+			title = "Community"
+		}
+		i, ok := groups[title]
+		if !ok {
+			i = len(g.groups)
+			groups[title] = i
+			g.groups = append(g.groups, graphGroup{id: nodeID("status", title), label: title})
+		}
+		name := d.Name
+		if name == "" {
+			name = d.Language
+		}
+		id := nodeID("driver", d.Language)
+		g.groups[i].drivers = append(g.groups[i].drivers, graphNode{id: id, label: name})
+
+		for _, m := range d.Maintainers {
+			mid := nodeID("maintainer", m.key())
+			if !seen[mid] {
+				seen[mid] = true
+				label := m.Name
+				if label == "" {
+					label = m.Github
+				}
+				g.maintainers = append(g.maintainers, graphNode{id: mid, label: label})
+			}
+			g.maintained = append(g.maintained, [2]string{id, mid})
+		}
+		if d.SDKVersion != "" {
+			sid := nodeID("sdk", d.SDKVersion)
+			if !seen[sid] {
+				seen[sid] = true
+				g.sdks = append(g.sdks, graphNode{id: sid, label: "SDK " + d.SDKVersion})
+			}
+			g.built = append(g.built, [2]string{id, sid})
+		}
+	}
+	return g
+}
+
+// WriteMermaid writes a Mermaid flowchart of the driver ecosystem, to be
+// embedded in the documentation.
+func WriteMermaid(w io.Writer, list []Driver) {
+	g := newEcosystem(list)
+	label := func(s string) string { return strings.Replace(s, `"`, "#quot;", -1) }
+
+	fmt.Fprintln(w, "graph LR")
+	for _, gr := range g.groups {
+		fmt.Fprintf(w, "  subgraph %s[\"%s\"]\n", gr.id, label(gr.label))
+		for _, n := range gr.drivers {
+			fmt.Fprintf(w, "    %s[\"%s\"]\n", n.id, label(n.label))
+		}
+		fmt.Fprintln(w, "  end")
+	}
+	for _, n := range g.maintainers {
+		fmt.Fprintf(w, "  %s([\"%s\"])\n", n.id, label(n.label))
+	}
+	for _, n := range g.sdks {
+		fmt.Fprintf(w, "  %s{{\"%s\"}}\n", n.id, label(n.label))
+	}
+	for _, e := range g.maintained {
+		fmt.Fprintf(w, "  %s --> %s\n", e[0], e[1])
+	}
+	for _, e := range g.built {
+		fmt.Fprintf(w, "  %s -.-> %s\n", e[0], e[1])
+	}
+}
+
+// WriteDOT writes a Graphviz graph of the driver ecosystem.
+func WriteDOT(w io.Writer, list []Driver) {
+	g := newEcosystem(list)
+	label := func(s string) string { return `"` + strings.Replace(s, `"`, `\"`, -1) + `"` }
+
+	fmt.Fprintln(w, "digraph drivers {")
+	fmt.Fprintln(w, "  rankdir=LR;")
+	for _, gr := range g.groups {
+		fmt.Fprintf(w, "  subgraph cluster_%s {\n", gr.id)
+		fmt.Fprintf(w, "    label=%s;\n", label(gr.label))
+		for _, n := range gr.drivers {
+			fmt.Fprintf(w, "    %s [label=%s, shape=box];\n", n.id, label(n.label))
+		}
+		fmt.Fprintln(w, "  }")
+	}
+	for _, n := range g.maintainers {
+		fmt.Fprintf(w, "  %s [label=%s, shape=ellipse];\n", n.id, label(n.label))
+	}
+	for _, n := range g.sdks {
+		fmt.Fprintf(w, "  %s [label=%s, shape=hexagon];\n", n.id, label(n.label))
+	}
+	for _, e := range g.maintained {
+		fmt.Fprintf(w, "  %s -> %s;\n", e[0], e[1])
+	}
+	for _, e := range g.built {
+		fmt.Fprintf(w, "  %s -> %s [style=dashed];\n", e[0], e[1])
+	}
+	fmt.Fprintln(w, "}")
+}
@@ -0,0 +1,163 @@
+package languages
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+const githubGraphQL = "https://api.github.com/graphql"
+
+// graphqlBatch is the number of repositories queried at once, to keep the
+// queries below the GitHub limits of complexity.
+const graphqlBatch = 25
+
+// graphqlRepo are the fields of a repository loaded with the GraphQL API,
+// that the REST API needs several requests for.
+type graphqlRepo struct {
+	NameWithOwner  string `json:"nameWithOwner"`
+	URL            string `json:"url"`
+	IsArchived     bool   `json:"isArchived"`
+	StargazerCount int    `json:"stargazerCount"`
+	ForkCount      int    `json:"forkCount"`
+	Issues         struct {
+		TotalCount int `json:"totalCount"`
+	} `json:"issues"`
+	PullRequests struct {
+		TotalCount int `json:"totalCount"`
+	} `json:"pullRequests"`
+	LicenseInfo *struct {
+		SPDXID string `json:"spdxId"`
+		Name   string `json:"name"`
+	} `json:"licenseInfo"`
+	DefaultBranchRef *struct {
+		Name   string `json:"name"`
+		Target struct {
+			CommittedDate *time.Time `json:"committedDate"`
+		} `json:"target"`
+	} `json:"defaultBranchRef"`
+	LatestRelease *struct {
+		TagName     string     `json:"tagName"`
+		PublishedAt *time.Time `json:"publishedAt"`
+	} `json:"latestRelease"`
+}
+
+const graphqlRepoFields = `nameWithOwner url isArchived stargazerCount forkCount
+	issues(states: OPEN) { totalCount }
+	pullRequests(states: OPEN) { totalCount }
+	licenseInfo { spdxId name }
+	defaultBranchRef { name target { ... on Commit { committedDate } } }
+	latestRelease { tagName publishedAt }`
+
+// prefetch loads the details of the repositories of all the drivers with
+// a few GraphQL queries, instead of several REST requests per driver, to
+// be used by loadRepo. The GraphQL API needs a token, so nothing is loaded
+// without GITHUB_TOKEN. The repositories not found, like the renamed ones,
+// are left to the REST API.
+func (g *githubClient) prefetch(list []Driver) error {
+	// the client is shared by the runs of -watch
+	g.mu.Lock()
+	g.repos = make(map[string]*graphqlRepo)
+	g.mu.Unlock()
+	if os.Getenv("GITHUB_TOKEN") == "" {
+		return nil
+	}
+	var repos []string
+	for i := range list {
+		if list[i].GithubURL != "" {
+			repos = append(repos, repoPath(&list[i]))
+		}
+	}
+	for len(repos) != 0 {
+		n := graphqlBatch
+		if n > len(repos) {
+			n = len(repos)
+		}
+		if err := g.queryRepos(repos[:n]); err != nil {
+			return err
+		}
+		repos = repos[n:]
+	}
+	return nil
+}
+
+// queryRepos loads the given repositories in a single GraphQL query, with
+// an alias for each one.
+func (g *githubClient) queryRepos(repos []string) error {
+	var q strings.Builder
+	q.WriteString("query {\n")
+	for i, repo := range repos {
+		owner, name := splitRepo(repo)
+		fmt.Fprintf(&q, "r%d: repository(owner: %q, name: %q) { %s }\n", i, owner, name, graphqlRepoFields)
+	}
+	q.WriteString("}")
+
+	body, err := json.Marshal(struct {
+		Query string `json:"query"`
+	}{q.String()})
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest("POST", githubGraphQL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	// the repositories not found are reported in the errors, and are null
+	// in the data
+	var resp struct {
+		Data map[string]*graphqlRepo `json:"data"`
+	}
+	if err := getJSON(g.cli, req, &resp); err != nil {
+		return err
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	for i, repo := range repos {
+		if r := resp.Data[fmt.Sprintf("r%d", i)]; r != nil {
+			g.repos[strings.ToLower(repo)] = r
+		}
+	}
+	return nil
+}
+
+// prefetched returns the details of the repository loaded by prefetch, if
+// it was.
+func (g *githubClient) prefetched(repo string) *graphqlRepo {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.repos[strings.ToLower(repo)]
+}
+
+// setPrefetched sets the fields of the driver loaded by prefetch, that the
+// REST API loads with loadInfo, loadLastCommit, loadPullRequests and
+// loadRelease, and returns the name of the default branch.
+func setPrefetched(r *graphqlRepo, d *Driver) string {
+	if r.URL != "" && !strings.EqualFold(r.NameWithOwner, repoPath(d)) {
+		d.GithubURL = r.URL
+	}
+	if d.Archived = r.IsArchived; d.Archived && d.Deprecated == "" {
+		d.Deprecated = archivedNote
+	}
+	d.Stars, d.Forks = r.StargazerCount, r.ForkCount
+	d.OpenIssues, d.OpenPullRequests = r.Issues.TotalCount, r.PullRequests.TotalCount
+	if l := r.LicenseInfo; l != nil {
+		if d.License = l.SPDXID; d.License == "" || d.License == "NOASSERTION" {
+			d.License = l.Name
+		}
+	}
+	if rel := r.LatestRelease; rel != nil {
+		d.LatestRelease, d.ReleaseDate = rel.TagName, rel.PublishedAt
+	}
+	b := r.DefaultBranchRef
+	if b == nil {
+		return ""
+	}
+	d.LastCommit = b.Target.CommittedDate
+	return b.Name
+}
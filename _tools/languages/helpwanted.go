@@ -0,0 +1,47 @@
+package languages
+
+import (
+	"io"
+
+	"gopkg.in/bblfsh/sdk.v1/manifest"
+)
+
+// missingTasks returns the missing pieces of the driver, as tasks linking
+// to its repository.
+func missingTasks(f Renderer, d Driver) []string {
+	repo := d.RepoURL()
+	var tasks []string
+	if d.DockerhubURL == "" {
+		tasks = append(tasks, f.Link("Publish a container image", repo))
+	}
+	if !d.Supports(manifest.UAST) {
+		tasks = append(tasks, f.Link("Support UAST", repo))
+	}
+	if !d.Supports(manifest.Roles) {
+		tasks = append(tasks, f.Link("Annotate the UAST with roles", repo))
+	}
+	if len(d.Maintainers) == 0 {
+		tasks = append(tasks, f.Link("Find a maintainer", repo))
+	}
+	return tasks
+}
+
+// writeHelpWanted writes the missing pieces of each official driver in
+// development, so readers find specific tasks to contribute to.
+func writeHelpWanted(w io.Writer, f Renderer, list []Driver) {
+	var dev []Driver
+	for _, d := range list {
+		if !d.Community && d.Status.Rank() < manifest.Alpha.Rank() && len(missingTasks(f, d)) != 0 {
+			dev = append(dev, d)
+		}
+	}
+	if len(dev) == 0 {
+		return
+	}
+	f.Heading(w, "Help wanted")
+	f.Paragraph(w, f.Text("These drivers in development are missing some pieces. Contributions are welcome!"))
+	for _, d := range dev {
+		f.Subheading(w, d.Language)
+		f.Tasks(w, missingTasks(f, d))
+	}
+}
@@ -0,0 +1,255 @@
+package languages
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/bblfsh/sdk.v1/manifest"
+)
+
+// trendRows is the maximum number of runs shown in the trend table.
+const trendRows = 12
+
+// HistoryEntry is the summary of a run, as stored in the history file.
+type HistoryEntry struct {
+	Date time.Time `json:"date"`
+	// Counts is the number of drivers by status.
+	Counts map[string]int `json:"counts"`
+	// Drivers is the status of each driver, by language.
+	Drivers map[string]string `json:"drivers"`
+	// Issues is the number of open issues of each driver, by language.
+	Issues map[string]int `json:"issues,omitempty"`
+}
+
+// NewHistoryEntry summarizes the drivers of a run at the given date.
+func NewHistoryEntry(date time.Time, list []Driver) HistoryEntry {
+	e := HistoryEntry{
+		Date:    date.UTC(),
+		Counts:  make(map[string]int),
+		Drivers: make(map[string]string, len(list)),
+		Issues:  make(map[string]int, len(list)),
+	}
+	for _, d := range list {
+		e.Counts[string(d.Status)]++
+		e.Drivers[d.Language] = string(d.Status)
+		e.Issues[d.Language] = d.OpenIssues
+	}
+	return e
+}
+
+// ReadHistory reads the entries of a history file, with one JSON entry
+// per line. A missing file is an empty history.
+func ReadHistory(path string) ([]HistoryEntry, error) {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var hist []HistoryEntry
+	sc := bufio.NewScanner(file)
+	for n := 1; sc.Scan(); n++ {
+		if strings.TrimSpace(sc.Text()) == "" {
+			continue
+		}
+		var e HistoryEntry
+		if err := json.Unmarshal(sc.Bytes(), &e); err != nil {
+			return nil, fmt.Errorf("%s:%d: %v", path, n, err)
+		}
+		hist = append(hist, e)
+	}
+	return hist, sc.Err()
+}
+
+// AppendHistory appends an entry to the history file, creating it if it
+// does not exist.
+func AppendHistory(path string, e HistoryEntry) error {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return err
+	}
+	if err := json.NewEncoder(file).Encode(e); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// quarterStart returns the first day of the quarter of t.
+func quarterStart(t time.Time) time.Time {
+	m := time.Month((int(t.Month())-1)/3*3 + 1)
+	return time.Date(t.Year(), m, 1, 0, 0, 0, 0, t.Location())
+}
+
+// writeTrend writes the promotions of drivers during the current quarter
+// and the number of drivers by status over the last runs. The history
+// must be in chronological order, ending with the current run.
+func writeTrend(w io.Writer, f Renderer, hist []HistoryEntry) {
+	if len(hist) == 0 {
+		return
+	}
+	cur := hist[len(hist)-1]
+
+	// the baseline is the last run before the quarter, or the first one
+	// in it if there is none
+	start := quarterStart(cur.Date)
+	base := hist[0]
+	for _, e := range hist {
+		if e.Date.After(start) {
+			break
+		}
+		base = e
+	}
+
+	promoted := make(map[string][]string)
+	var added []string
+	for lang, st := range cur.Drivers {
+		old, ok := base.Drivers[lang]
+		if !ok {
+			added = append(added, lang)
+		} else if manifest.DevelopmentStatus(st).Rank() > manifest.DevelopmentStatus(old).Rank() {
+			promoted[st] = append(promoted[st], lang)
+		}
+	}
+
+	f.Heading(w, "Trend")
+	var items []string
+	if len(added) != 0 {
+		sort.Strings(added)
+		items = append(items, fmt.Sprintf("%s added this quarter (%s).",
+			plural(len(added), "driver"), strings.Join(added, ", ")))
+	}
+	for _, st := range historyStatuses(hist) {
+		langs := promoted[st]
+		if len(langs) == 0 {
+			continue
+		}
+		sort.Strings(langs)
+		items = append(items, fmt.Sprintf("%s promoted to %s this quarter (%s).",
+			plural(len(langs), "driver"), st, strings.Join(langs, ", ")))
+	}
+	if len(items) == 0 {
+		items = append(items, "No driver changed its status this quarter.")
+	}
+	for _, s := range items {
+		f.Paragraph(w, f.Text(s))
+	}
+
+	last := lastRuns(hist, trendRows)
+	if _, ok := f.(HTML); ok {
+		writeTrendChart(w, last)
+	}
+	statuses := historyStatuses(last)
+	header := []string{"Date"}
+	for _, st := range statuses {
+		header = append(header, orDash(st))
+	}
+	rows := make([][]string, 0, len(last))
+	for _, e := range last {
+		row := []string{f.Text(formatDate(&e.Date))}
+		for _, st := range statuses {
+			row = append(row, f.Text(strconv.Itoa(e.Counts[st])))
+		}
+		rows = append(rows, row)
+	}
+	f.Table(w, header, rows)
+}
+
+// lastRuns returns the last run of each day, up to n days.
+func lastRuns(hist []HistoryEntry, n int) []HistoryEntry {
+	var out []HistoryEntry
+	for _, e := range hist {
+		if k := len(out) - 1; k >= 0 && formatDate(&out[k].Date) == formatDate(&e.Date) {
+			out[k] = e
+			continue
+		}
+		out = append(out, e)
+	}
+	if len(out) > n {
+		out = out[len(out)-n:]
+	}
+	return out
+}
+
+// historyStatuses returns the statuses found in the history, from the
+// most to the least mature.
+func historyStatuses(hist []HistoryEntry) []string {
+	seen := make(map[string]bool)
+	var out []string
+	for _, e := range hist {
+		for st := range e.Counts {
+			if !seen[st] {
+				seen[st] = true
+				out = append(out, st)
+			}
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		ri := manifest.DevelopmentStatus(out[i]).Rank()
+		rj := manifest.DevelopmentStatus(out[j]).Rank()
+		if ri != rj {
+			return ri > rj
+		}
+		return out[i] < out[j]
+	})
+	return out
+}
+
+func plural(n int, noun string) string {
+	if n == 1 {
+		return "1 " + noun
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
+
+// chartColors are the colors of the statuses in the trend chart, from the
+// most to the least mature.
+var chartColors = []string{colorGreen, "#97ca00", colorYellow, "#fe7d37", colorRed, "#9f9f9f", "#555"}
+
+// writeTrendChart writes an SVG chart with a stacked bar of the drivers by
+// status for each run.
+func writeTrendChart(w io.Writer, hist []HistoryEntry) {
+	const (
+		barWidth = 24
+		gap      = 8
+		unit     = 6
+	)
+	statuses := historyStatuses(hist)
+	max := 0
+	for _, e := range hist {
+		n := 0
+		for _, c := range e.Counts {
+			n += c
+		}
+		if n > max {
+			max = n
+		}
+	}
+	width, height := len(hist)*(barWidth+gap), max*unit
+
+	fmt.Fprintf(w, "<svg class=\"trend\" width=\"%d\" height=\"%d\">\n", width, height)
+	for i, e := range hist {
+		y := height
+		for j, st := range statuses {
+			n := e.Counts[st]
+			if n == 0 {
+				continue
+			}
+			y -= n * unit
+			fmt.Fprintf(w, "<rect x=\"%d\" y=\"%d\" width=\"%d\" height=\"%d\" fill=\"%s\"><title>%s: %d %s</title></rect>\n",
+				i*(barWidth+gap), y, barWidth, n*unit, chartColors[j%len(chartColors)],
+				formatDate(&e.Date), n, html.EscapeString(st))
+		}
+	}
+	fmt.Fprint(w, "</svg>\n")
+}
@@ -0,0 +1,111 @@
+package languages
+
+import (
+	"context"
+	"crypto/tls"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// Proxy overrides the proxy of the requests to the services, taken from
+// the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables by
+// default. Both HTTP and SOCKS5 proxies are supported, like
+// "socks5://localhost:1080". It must be set before any request is made.
+var Proxy *url.URL
+
+var (
+	transportOnce   sync.Once
+	sharedTransport http.RoundTripper
+)
+
+// newTransport returns the transport shared by the requests to all the
+// services, so the connections and TLS sessions to the same hosts, like
+// the GitHub API for each driver, are reused.
+func newTransport() http.RoundTripper {
+	transportOnce.Do(func() {
+		t := &http.Transport{
+			Proxy: proxyFor,
+			DialContext: (&net.Dialer{
+				Timeout:   30 * time.Second,
+				KeepAlive: 30 * time.Second,
+			}).DialContext,
+			ForceAttemptHTTP2:     true,
+			MaxIdleConns:          100,
+			MaxIdleConnsPerHost:   16,
+			IdleConnTimeout:       90 * time.Second,
+			TLSHandshakeTimeout:   10 * time.Second,
+			ExpectContinueTimeout: time.Second,
+			TLSClientConfig: &tls.Config{
+				ClientSessionCache: tls.NewLRUClientSessionCache(64),
+			},
+		}
+		sharedTransport = cancelTransport{t}
+	})
+	return sharedTransport
+}
+
+var shutdownCtx, shutdown = context.WithCancel(context.Background())
+
+// Shutdown cancels the requests in flight of the shared transport, and
+// makes the next ones fail, to stop a run gracefully.
+func Shutdown() {
+	shutdown()
+}
+
+// cancelTransport cancels the requests when Shutdown is called.
+type cancelTransport struct {
+	base http.RoundTripper
+}
+
+func (t cancelTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, cancel := context.WithCancel(req.Context())
+	go func() {
+		select {
+		case <-shutdownCtx.Done():
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	resp, err := t.base.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	// the body is read after the request returns
+	resp.Body = cancelBody{resp.Body, cancel}
+	return resp, nil
+}
+
+type cancelBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b cancelBody) Close() error {
+	defer b.cancel()
+	return b.ReadCloser.Close()
+}
+
+// InstallTransport makes the shared transport the default one of net/http,
+// so the libraries that use http.DefaultClient, like the discovery of the
+// SDK, also reuse its connections. It must be called after setting Proxy.
+func InstallTransport() {
+	http.DefaultTransport = newTransport()
+}
+
+func proxyFor(req *http.Request) (*url.URL, error) {
+	if Proxy != nil {
+		return Proxy, nil
+	}
+	return http.ProxyFromEnvironment(req)
+}
+
+// NewHTTPClient returns a client for the requests to the services, using
+// Proxy.
+func NewHTTPClient(timeout time.Duration) *http.Client {
+	return &http.Client{Timeout: timeout, Transport: newTransport()}
+}
@@ -0,0 +1,59 @@
+package languages
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// driverIndex is the index of the driver images, for tools that install
+// them like bblfshctl.
+type driverIndex struct {
+	Version int                `json:"version"`
+	Drivers []driverIndexEntry `json:"drivers"`
+}
+
+type driverIndexEntry struct {
+	Language string `json:"language"`
+	Status   string `json:"status"`
+	Official bool   `json:"official"`
+	Image    string `json:"image"`
+	// Tag is the recommended tag, the latest version if there is one.
+	Tag    string `json:"tag"`
+	Digest string `json:"digest,omitempty"`
+	// Reference is the image reference to pull, pinned to the digest when
+	// it is known.
+	Reference string `json:"reference"`
+}
+
+// WriteIndex writes a JSON index of the published driver images, with
+// their recommended tags and digests, like:
+//
+//	{"version": 1, "drivers": [{"language": "go", "status": "beta",
+//	"official": true, "image": "bblfsh/go-driver", "tag": "v2.1.0",
+//	"digest": "sha256:...", "reference": "bblfsh/go-driver:v2.1.0@sha256:..."}]}
+//
+// Drivers without a published image are not listed.
+func WriteIndex(w io.Writer, list []Driver) error {
+	idx := driverIndex{Version: apiVersion, Drivers: []driverIndexEntry{}}
+	for _, d := range list {
+		if d.DockerhubURL == "" {
+			continue
+		}
+		e := driverIndexEntry{
+			Language:  d.Language,
+			Status:    string(d.Status),
+			Official:  !d.Community,
+			Image:     d.Image,
+			Tag:       d.RecommendedTag(),
+			Digest:    d.Digest,
+			Reference: d.Image + ":" + d.RecommendedTag(),
+		}
+		if d.Digest != "" {
+			e.Reference += "@" + d.Digest
+		}
+		idx.Drivers = append(idx.Drivers, e)
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "\t")
+	return enc.Encode(idx)
+}
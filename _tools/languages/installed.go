@@ -0,0 +1,52 @@
+package languages
+
+import (
+	"fmt"
+	"strings"
+
+	bblfsh "gopkg.in/bblfsh/client-go.v2"
+)
+
+// LoadInstalled sets the version of the drivers installed in the bblfshd
+// daemon listening on addr, like "localhost:9432".
+func LoadInstalled(addr string, list []Driver) error {
+	cli, err := bblfsh.NewClient(addr)
+	if err != nil {
+		return fmt.Errorf("cannot connect to bblfshd at %s: %v", addr, err)
+	}
+	defer cli.Close()
+	res, err := cli.NewSupportedLanguagesRequest().Do()
+	if err != nil {
+		return fmt.Errorf("cannot list the drivers of bblfshd at %s: %v", addr, err)
+	}
+	if len(res.Errors) != 0 {
+		return fmt.Errorf("cannot list the drivers of bblfshd at %s: %s", addr, strings.Join(res.Errors, "; "))
+	}
+	installed := make(map[string]string, len(res.Languages))
+	for _, m := range res.Languages {
+		installed[m.Language] = m.Version
+	}
+	for i := range list {
+		list[i].InstalledVersion = installed[list[i].Language]
+	}
+	return nil
+}
+
+// installedCell renders the installed version of the driver, highlighted
+// if it is not the latest one.
+func installedCell(f Renderer, d Driver) string {
+	v := d.InstalledVersion
+	switch {
+	case v == "":
+		return f.Text("-")
+	case d.LatestVersion == "" || sameVersion(v, d.LatestVersion):
+		return f.Badge(v, colorGreen)
+	}
+	return f.Badge(fmt.Sprintf("%s (latest %s)", v, d.LatestVersion), colorYellow)
+}
+
+// sameVersion compares versions with or without the "v" prefix, as the
+// drivers report them without it but the image tags have it.
+func sameVersion(a, b string) bool {
+	return strings.TrimPrefix(a, "v") == strings.TrimPrefix(b, "v")
+}
@@ -0,0 +1,298 @@
+// Package languages discovers the Babelfish drivers, fills their details
+// from Docker Hub, GitHub and other services, and renders them as the
+// tables and pages of the documentation.
+package languages
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/bblfsh/sdk.v1/manifest/discovery"
+)
+
+// DiscoverOptions selects the drivers found by Discover, in addition to
+// the official ones.
+type DiscoverOptions struct {
+	// Community is a YAML file listing community drivers.
+	Community string
+	// GitlabGroup is a GitLab group to discover additional drivers from,
+	// in the GitLab instance at GitlabURL, https://gitlab.com by default.
+	GitlabGroup string
+	GitlabURL   string
+	// GitlabToken is the access token for private GitLab groups.
+	GitlabToken string
+	// Report is called with the problems found in the drivers, that are
+	// skipped. The problems are logged if it is nil.
+	Report func(Problem)
+	// Overrides is a YAML file correcting the details of the discovered
+	// drivers, like their names.
+	Overrides string
+	// Ref pins the manifests of the official drivers to a commit, tag or
+	// branch of their repositories, to discover the same drivers later.
+	Ref string
+	// Fallback lists the official drivers if the discovery in GitHub fails,
+	// like RegistrySource or CacheSource. Discover fails if it is nil.
+	Fallback DriverSource
+	// Degraded is called with the reason the official drivers are listed
+	// from Fallback, like DocumentOptions.Degraded.
+	Degraded func(reason string)
+	// Sources replace the sources selected by the other options, if set.
+	Sources []DriverSource
+}
+
+// sources returns the sources of drivers selected in the options.
+func (o *DiscoverOptions) sources() []DriverSource {
+	if len(o.Sources) != 0 {
+		return o.Sources
+	}
+	srcs := []DriverSource{OfficialSource{
+		Fallback: o.Fallback,
+		Degraded: o.Degraded,
+		Ref:      o.Ref,
+		Report:   o.Report,
+	}}
+	if o.GitlabGroup != "" {
+		srcs = append(srcs, GitlabSource{
+			URL:    o.GitlabURL,
+			Group:  o.GitlabGroup,
+			Token:  o.GitlabToken,
+			Report: o.Report,
+		})
+	}
+	if o.Community != "" {
+		srcs = append(srcs, CommunitySource{Path: o.Community, Report: o.Report})
+	}
+	return srcs
+}
+
+// Discover returns the drivers of the sources selected in the options,
+// sorted by status and then by language, with the community drivers last.
+// The order does not depend on the one of the sources, so the generated
+// documents only change when the drivers do. The drivers with an invalid
+// or duplicated language key are reported and skipped. Only the details of
+// the manifests are set, corrected by the overrides; see Enrich.
+func Discover(ctx context.Context, opts *DiscoverOptions) ([]Driver, error) {
+	if opts == nil {
+		opts = &DiscoverOptions{}
+	}
+	var list []Driver
+	for _, src := range opts.sources() {
+		ds, err := src.Drivers(ctx)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, ds...)
+	}
+	list = checkKeys(list, reporter(opts.Report))
+	if opts.Overrides != "" {
+		if err := applyOverrides(opts.Overrides, list, reporter(opts.Report)); err != nil {
+			return nil, err
+		}
+	}
+	sort.SliceStable(list, func(i, j int) bool {
+		a, b := list[i], list[j]
+		if a.Community != b.Community {
+			return !a.Community
+		}
+		if ra, rb := a.Status.Rank(), b.Status.Rank(); ra != rb {
+			return ra > rb
+		}
+		if a.Language != b.Language {
+			return a.Language < b.Language
+		}
+		return a.RepoURL() < b.RepoURL()
+	})
+	return list, nil
+}
+
+// languageKey matches the language keys that are safe to use in paths,
+// since files like the pages and the API are named after them.
+var languageKey = regexp.MustCompile(`^[a-z0-9][a-z0-9_+#-]*$`)
+
+// checkKeys returns the drivers with a valid and unique language key,
+// reporting the others. Of the drivers with the same key, the first one
+// is kept, so the official drivers take precedence.
+func checkKeys(list []Driver, report func(Problem)) []Driver {
+	seen := make(map[string]Driver, len(list))
+	out := list[:0]
+	for _, d := range list {
+		if !languageKey.MatchString(d.Language) {
+			report(Problem{Msg: fmt.Sprintf("%s: invalid language key %q, skipping the driver", d.RepoURL(), d.Language)})
+			continue
+		}
+		if prev, ok := seen[d.Language]; ok {
+			report(Problem{Msg: fmt.Sprintf("%s: language key %q already used by %s, skipping the driver", d.RepoURL(), d.Language, prev.RepoURL())})
+			continue
+		}
+		seen[d.Language] = d
+		out = append(out, d)
+	}
+	return out
+}
+
+type Driver struct {
+	discovery.Driver
+	// Source is the code hosting service where the driver was discovered.
+	Source string `json:",omitempty"`
+	// GitlabURL is the repository of drivers discovered in GitLab.
+	GitlabURL string `json:",omitempty"`
+	// Image is the name of the driver image in Docker Hub.
+	Image string `json:",omitempty"`
+	// Community is set for drivers not maintained by the bblfsh
+	// organization, listed in DiscoverOptions.Community.
+	Community bool `json:",omitempty"`
+	// Aliases are the other identifiers of the language accepted by the
+	// parsing endpoints, as declared in the driver manifest.
+	Aliases []string `json:",omitempty"`
+	// Extensions are the file extensions of the language, with the leading
+	// dot, as declared in the driver manifest.
+	Extensions []string `json:",omitempty"`
+	// DocumentationURL is the documentation of the driver, only set by the
+	// overrides.
+	DocumentationURL string `json:",omitempty"`
+	// Deprecated is a note explaining that the driver is deprecated, set by
+	// the overrides or when its repository is archived.
+	Deprecated string `json:",omitempty"`
+	// Archived is set if the repository of the driver is archived.
+	Archived bool `json:",omitempty"`
+	// Overrides are the details replaced by DiscoverOptions.Overrides.
+	Overrides []Override `json:",omitempty"`
+	// Maintainers replaces the maintainers of the discovered driver, to
+	// include the details of their profiles.
+	Maintainers  []Maintainer `json:",omitempty"`
+	GithubURL    string       `json:",omitempty"`
+	DockerhubURL string       `json:",omitempty"`
+	// Degraded is the reason the driver was listed from a fallback source,
+	// since its discovery failed, if it was.
+	Degraded string `json:",omitempty"`
+	// Commit is the commit of the repository the manifest was read from,
+	// only set if the discovery is pinned with DiscoverOptions.Ref.
+	Commit string `json:",omitempty"`
+	// ImageWorks is the result of the smoke test of the latest image, only
+	// set with EnrichOptions.SmokeTest.
+	ImageWorks *bool `json:",omitempty"`
+	// InstalledVersion is the version installed in the bblfshd given to
+	// LoadInstalled, if any.
+	InstalledVersion string `json:",omitempty"`
+	// LatestVersion is the newest semver tag published for the driver image.
+	LatestVersion string `json:",omitempty"`
+	// Digest is the content digest of the recommended tag of the image.
+	Digest string `json:",omitempty"`
+	// ImageSize is the compressed size of the latest image, in bytes.
+	ImageSize int64 `json:",omitempty"`
+	// ImagePushed is the last time the latest image was pushed.
+	ImagePushed *time.Time `json:",omitempty"`
+	// Architectures lists the platforms the latest image is available for.
+	Architectures []string `json:",omitempty"`
+	// PullCount is the number of pulls of the image reported by Docker Hub.
+	PullCount int64 `json:",omitempty"`
+	// License is the SPDX identifier of the driver license, as detected by
+	// GitHub.
+	License string `json:",omitempty"`
+	// Stars and Forks are the stargazers and forks of the driver repository.
+	Stars int
+	Forks int
+	// OpenIssues and OpenPullRequests are the open issues and pull requests
+	// of the driver repository.
+	OpenIssues       int
+	OpenPullRequests int
+	// IssueGrowth is the growth of the open issues in the runs of the
+	// history, only set by SetIssueGrowth.
+	IssueGrowth int `json:",omitempty"`
+	// LastCommit is the date of the last commit on the default branch.
+	LastCommit *time.Time `json:",omitempty"`
+	// BuildStatus is the CI status of the default branch: passing, failing,
+	// pending, or empty if the repository has no CI.
+	BuildStatus string `json:",omitempty"`
+	// SDKVersion is the version of the bblfsh SDK the driver is built with.
+	SDKVersion string `json:",omitempty"`
+	// Protocols are the versions of the bblfsh protocol the driver
+	// implements, as known from its SDK version, like "v1" and "v2".
+	Protocols []string `json:",omitempty"`
+	// Coverage is the line coverage percentage of the driver tests. It is
+	// only set with EnrichOptions.Coverage.
+	Coverage *float64 `json:",omitempty"`
+	// Fixtures is the number of parser test fixtures of the driver, a proxy
+	// of how much of the grammar is covered.
+	Fixtures int `json:",omitempty"`
+	// NativeExampleURL and UASTExampleURL link to the native AST and UAST
+	// of a fixture of the driver, to show the shape of its output.
+	NativeExampleURL string `json:",omitempty"`
+	UASTExampleURL   string `json:",omitempty"`
+	// LatestRelease is the tag of the latest GitHub release of the driver.
+	LatestRelease string `json:",omitempty"`
+	// ReleaseDate is the publication date of the latest GitHub release.
+	ReleaseDate *time.Time `json:",omitempty"`
+	// Sample is the source parsed to produce UASTExample.
+	Sample string `json:",omitempty"`
+	// UASTExample is the beginning of the UAST of Sample, as returned by
+	// the latest image of the driver.
+	UASTExample string `json:",omitempty"`
+	// Vulnerabilities are the high and critical vulnerabilities of the
+	// latest image of the driver, only set with EnrichOptions.Scan.
+	Vulnerabilities *Vulnerabilities `json:",omitempty"`
+	// Extra are the fields added by plugins, by name.
+	Extra map[string]string `json:",omitempty"`
+	// Performance are the measures of parsing the benchmark corpus with the
+	// latest image of the driver.
+	Performance *Performance `json:",omitempty"`
+}
+
+// RepoURL returns the URL of the driver repository, wherever it is hosted.
+func (m Driver) RepoURL() string {
+	if m.GithubURL != "" {
+		return m.GithubURL
+	}
+	return m.GitlabURL
+}
+
+// languageVersions returns the versions of the language supported by the
+// driver, as declared in the runtime of its manifest.
+func (m Driver) languageVersions() string {
+	return strings.Join(m.Runtime.NativeVersion, ", ")
+}
+
+// otherFeatures returns the features declared in the manifest of the
+// driver that have no column in the default table.
+func (m Driver) otherFeatures() []string {
+	var out []string
+features:
+	for _, ft := range m.Features {
+		for _, def := range defaultFeatures {
+			if ft == def {
+				continue features
+			}
+		}
+		out = append(out, string(ft))
+	}
+	return out
+}
+
+// RecommendedTag returns the tag of the image to install, the latest
+// version if there is one.
+func (m Driver) RecommendedTag() string {
+	if m.LatestVersion != "" {
+		return m.LatestVersion
+	}
+	return "latest"
+}
+
+// backlog is the number of open issues and pull requests of the driver.
+func (m Driver) backlog() int {
+	return m.OpenIssues + m.OpenPullRequests
+}
+
+func boolIcon(v bool) string {
+	if v {
+		return "✓"
+	}
+	return "✗"
+}
+
+func linkMark(f Renderer, url string) string {
+	return f.Mark(url != "", url)
+}
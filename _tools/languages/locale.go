@@ -0,0 +1,111 @@
+package languages
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Messages are the translations of the texts of the generated documents,
+// keyed by their English text, like "Supported languages" or "Status".
+// The "header", "footer" and "legend" keys replace the whole content
+// returned by the methods of the Renderer with the same name.
+type Messages map[string]string
+
+// ReadMessages reads the messages of a locale from a YAML file.
+func ReadMessages(path string) (Messages, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var msgs Messages
+	if err := yaml.Unmarshal(data, &msgs); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	return msgs, nil
+}
+
+// ReadLocales reads the message files of a directory, named after their
+// locale like "es.yml", and returns them by locale.
+func ReadLocales(dir string) (map[string]Messages, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.yml"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+	out := make(map[string]Messages, len(paths))
+	for _, path := range paths {
+		msgs, err := ReadMessages(path)
+		if err != nil {
+			return nil, err
+		}
+		out[strings.TrimSuffix(filepath.Base(path), ".yml")] = msgs
+	}
+	return out, nil
+}
+
+// countSuffix matches the number of drivers added to some headings.
+var countSuffix = regexp.MustCompile(` \(\d+\)$`)
+
+// translate returns the translation of s, or s itself if there is none.
+// The count at the end of headings is kept.
+func (m Messages) translate(s string) string {
+	if t, ok := m[s]; ok {
+		return t
+	}
+	if loc := countSuffix.FindStringIndex(s); loc != nil {
+		if t, ok := m[s[:loc[0]]]; ok {
+			return t + s[loc[0]:]
+		}
+	}
+	return s
+}
+
+// Localize returns a renderer that translates the headings, table headers,
+// paragraphs and surrounding content rendered by f with the given
+// messages. The details of the drivers are not translated.
+func Localize(f Renderer, msgs Messages) Renderer {
+	return localized{Renderer: f, msgs: msgs}
+}
+
+type localized struct {
+	Renderer
+	msgs Messages
+}
+
+func (l localized) Header() string { return l.replace("header", l.Renderer.Header()) }
+func (l localized) Footer() string { return l.replace("footer", l.Renderer.Footer()) }
+func (l localized) Legend() string { return l.replace("legend", l.Renderer.Legend()) }
+
+func (l localized) replace(key, def string) string {
+	if t, ok := l.msgs[key]; ok {
+		return t
+	}
+	return def
+}
+
+func (l localized) Heading(w io.Writer, title string) {
+	l.Renderer.Heading(w, l.msgs.translate(title))
+}
+
+func (l localized) Subheading(w io.Writer, title string) {
+	l.Renderer.Subheading(w, l.msgs.translate(title))
+}
+
+func (l localized) Paragraph(w io.Writer, text string) {
+	l.Renderer.Paragraph(w, l.msgs.translate(text))
+}
+
+func (l localized) Table(w io.Writer, header []string, rows [][]string) {
+	tr := make([]string, 0, len(header))
+	for _, h := range header {
+		tr = append(tr, l.msgs.translate(h))
+	}
+	l.Renderer.Table(w, tr, rows)
+}
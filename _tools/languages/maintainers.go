@@ -0,0 +1,148 @@
+package languages
+
+import (
+	"io"
+	"sort"
+	"strings"
+	"sync"
+
+	"gopkg.in/bblfsh/sdk.v1/manifest/discovery"
+)
+
+// Maintainer is a driver maintainer, with the details of their GitHub
+// profile when they were requested.
+type Maintainer struct {
+	discovery.Maintainer
+	// FullName is the name set in the GitHub profile.
+	FullName string `json:",omitempty"`
+	// AvatarURL is the GitHub avatar of the maintainer.
+	AvatarURL string `json:",omitempty"`
+}
+
+// URL returns the profile of the maintainer, or a mailto link if they have
+// no GitHub account.
+func (m Maintainer) URL() string {
+	if m.Github != "" {
+		return `https://github.com/` + m.Github
+	} else if m.Email != "" {
+		return `mailto:` + m.Email
+	}
+	return ""
+}
+
+// key identifies the maintainer across drivers.
+func (m Maintainer) key() string {
+	if m.Github != "" {
+		return strings.ToLower(m.Github)
+	}
+	return strings.ToLower(m.Name)
+}
+
+func newMaintainers(list []discovery.Maintainer) []Maintainer {
+	out := make([]Maintainer, 0, len(list))
+	for _, m := range list {
+		out = append(out, Maintainer{Maintainer: m})
+	}
+	return out
+}
+
+type githubUser struct {
+	Name      string `json:"name"`
+	AvatarURL string `json:"avatar_url"`
+}
+
+// profiles caches the GitHub profiles of the maintainers, since most of
+// them maintain several drivers.
+type profiles struct {
+	g *githubClient
+
+	mu    sync.Mutex
+	users map[string]*githubUser
+}
+
+func newProfiles(g *githubClient) *profiles {
+	return &profiles{g: g, users: make(map[string]*githubUser)}
+}
+
+func (p *profiles) get(login string) (*githubUser, error) {
+	p.mu.Lock()
+	u, ok := p.users[login]
+	p.mu.Unlock()
+	if ok {
+		emit(Event{Kind: EventCacheHit, Source: "profiles", Msg: login})
+		return u, nil
+	}
+	emit(Event{Kind: EventCacheMiss, Source: "profiles", Msg: login})
+
+	u = new(githubUser)
+	if err := p.g.get("users/"+login, u); err != nil {
+		return nil, err
+	}
+
+	p.mu.Lock()
+	p.users[login] = u
+	p.mu.Unlock()
+	return u, nil
+}
+
+// loadMaintainers sets the name and avatar of the driver maintainers.
+func (p *profiles) loadMaintainers(d *Driver) {
+	for i := range d.Maintainers {
+		m := &d.Maintainers[i]
+		if m.Github == "" {
+			continue
+		}
+		u, err := p.get(m.Github)
+		if err != nil {
+			enrichFailed("profiles", "cannot get GitHub profile of %s: %v", m.Github, err)
+			continue
+		}
+		m.FullName, m.AvatarURL = u.Name, u.AvatarURL
+	}
+}
+
+// maintainerLink renders the avatar of the maintainer, if known, followed
+// by a link to their profile.
+func maintainerLink(f Renderer, m Maintainer) string {
+	name := m.Name
+	if m.Github != "" {
+		name = m.Github
+	}
+	var avatar string
+	if m.AvatarURL != "" {
+		avatar = f.Image(m.AvatarURL, name)
+	}
+	return avatar + f.Link(name, m.URL())
+}
+
+// writeMaintainers writes the page listing the drivers of each maintainer.
+func writeMaintainers(w io.Writer, f Renderer, list []Driver) {
+	var (
+		people  []Maintainer
+		drivers = make(map[string][]string)
+	)
+	for _, d := range list {
+		for _, m := range d.Maintainers {
+			k := m.key()
+			if _, ok := drivers[k]; !ok {
+				people = append(people, m)
+			}
+			drivers[k] = append(drivers[k], f.Link(d.Language, d.RepoURL()))
+		}
+	}
+	sort.Slice(people, func(i, j int) bool {
+		return people[i].key() < people[j].key()
+	})
+
+	rows := make([][]string, 0, len(people))
+	for _, m := range people {
+		rows = append(rows, []string{
+			maintainerLink(f, m),
+			f.Text(orDash(m.FullName)),
+			f.List(drivers[m.key()]),
+		})
+	}
+
+	f.Heading(w, "Maintainers")
+	f.Table(w, []string{"Maintainer", "Name", "Drivers"}, rows)
+}
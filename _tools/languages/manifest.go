@@ -0,0 +1,104 @@
+package languages
+
+import (
+	"bufio"
+	"bytes"
+	"strconv"
+	"strings"
+)
+
+// loadManifestLists sets the lists declared in the manifest of the driver
+// that the discovery of the SDK v1 does not decode.
+func (g *githubClient) loadManifestLists(repo, branch string, d *Driver) error {
+	if len(d.Aliases) != 0 && len(d.Extensions) != 0 {
+		return nil
+	}
+	data, err := g.rawFile(repo, branch, "manifest.toml")
+	if err == errNotFound {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	setManifestLists(d, data)
+	return nil
+}
+
+// setManifestLists sets the aliases and file extensions of the driver from
+// its manifest.toml, unless they are already set.
+func setManifestLists(d *Driver, data []byte) {
+	if len(d.Aliases) == 0 {
+		d.Aliases = manifestList(data, "aliases")
+	}
+	if len(d.Extensions) == 0 {
+		d.Extensions = manifestList(data, "extensions")
+	}
+}
+
+// manifestList returns a top-level array of strings of a manifest.toml,
+// like:
+//
+//	aliases = ["golang", "go"]
+//
+// The array may span several lines. Keys inside tables are ignored.
+func manifestList(data []byte, key string) []string {
+	var (
+		val    string
+		inList bool
+	)
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	for sc.Scan() {
+		line := stripComment(strings.TrimSpace(sc.Text()))
+		if inList {
+			val += " " + line
+		} else if strings.HasPrefix(line, "[") {
+			// the first table ends the top-level keys
+			return nil
+		} else if i := strings.Index(line, "="); i >= 0 && strings.TrimSpace(line[:i]) == key {
+			val, inList = strings.TrimSpace(line[i+1:]), true
+		} else {
+			continue
+		}
+		if strings.HasSuffix(val, "]") {
+			break
+		}
+	}
+	if !strings.HasPrefix(val, "[") || !strings.HasSuffix(val, "]") {
+		return nil
+	}
+
+	var out []string
+	for _, s := range strings.Split(val[1:len(val)-1], ",") {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+		if u, err := strconv.Unquote(s); err == nil {
+			s = u
+		} else {
+			s = strings.Trim(s, `'`)
+		}
+		if s != "" {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// stripComment removes a trailing comment from a TOML line, unless the
+// hash is inside a string.
+func stripComment(line string) string {
+	var quote rune
+	for i, r := range line {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '#':
+			return strings.TrimSpace(line[:i])
+		}
+	}
+	return line
+}
@@ -0,0 +1,55 @@
+package languages
+
+import (
+	"bytes"
+	"strings"
+	"time"
+)
+
+// metadataPrefix starts the comment with the metadata of a generated
+// document.
+const metadataPrefix = "<!-- Generated by "
+
+// Metadata describes how a document was generated, to be written as a
+// comment at its top.
+type Metadata struct {
+	// Version is the version of the tool.
+	Version string
+	// Date is when the document was generated. It is omitted if zero, for
+	// reproducible builds.
+	Date time.Time
+	// Sources are the places the drivers were discovered from.
+	Sources []string
+	// Ref is the ref the manifests of the drivers were pinned to, if any.
+	Ref string
+}
+
+// comment returns the metadata as a single line comment, valid in both
+// markdown and HTML.
+func (m Metadata) comment() string {
+	s := "languages " + orDash(m.Version)
+	if !m.Date.IsZero() {
+		s += " on " + m.Date.UTC().Format(time.RFC3339)
+	}
+	if len(m.Sources) != 0 {
+		s += " from " + strings.Join(m.Sources, ", ")
+	}
+	if m.Ref != "" {
+		s += " at " + m.Ref
+	}
+	// a double dash would end the comment early
+	return metadataPrefix + strings.Replace(s, "--", "-", -1) + " -->\n"
+}
+
+// StripMetadata removes the metadata comment from a generated document, so
+// documents generated at different times can be compared.
+func StripMetadata(data []byte) []byte {
+	lines := bytes.SplitAfter(data, []byte("\n"))
+	out := make([]byte, 0, len(data))
+	for _, l := range lines {
+		if !bytes.HasPrefix(l, []byte(metadataPrefix)) {
+			out = append(out, l...)
+		}
+	}
+	return out
+}
@@ -0,0 +1,126 @@
+package languages
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// enrichErrors counts the failures to load the details of the drivers, by
+// the source of the details, and keeps them for EnrichFailures.
+var enrichErrors = struct {
+	sync.Mutex
+	count    map[string]int
+	failures []EnrichFailure
+}{count: make(map[string]int)}
+
+// maxEnrichFailures is the number of failures kept for EnrichFailures.
+const maxEnrichFailures = 1000
+
+// EnrichFailure is a failure to load a detail of a driver.
+type EnrichFailure struct {
+	// Source is the service or tool the detail is loaded from, like
+	// "docker" or "github".
+	Source string
+	Msg    string
+}
+
+// enrichFailed logs a failure to load a detail of a driver and counts it.
+func enrichFailed(source, format string, args ...interface{}) {
+	msg := fmt.Sprintf(format, args...)
+	enrichErrors.Lock()
+	enrichErrors.count[source]++
+	// bounded, since the server keeps enriching the drivers
+	if len(enrichErrors.failures) < maxEnrichFailures {
+		enrichErrors.failures = append(enrichErrors.failures, EnrichFailure{Source: source, Msg: msg})
+	}
+	enrichErrors.Unlock()
+	emit(Event{Kind: EventError, Source: source, Msg: msg})
+	log.Print(msg)
+}
+
+// EnrichFailures returns the failures to load the details of the drivers
+// since the program started, in the order they happened. Only the first
+// ones are kept.
+func EnrichFailures() []EnrichFailure {
+	enrichErrors.Lock()
+	defer enrichErrors.Unlock()
+	return append([]EnrichFailure(nil), enrichErrors.failures...)
+}
+
+// WriteMetrics writes the health of the drivers in the Prometheus text
+// exposition format.
+func WriteMetrics(w io.Writer, list []Driver, updated time.Time) {
+	fmt.Fprint(w, "# HELP bblfsh_driver_status Development status of the driver, as its rank from inactive (0) to mature (6).\n")
+	fmt.Fprint(w, "# TYPE bblfsh_driver_status gauge\n")
+	for _, d := range list {
+		fmt.Fprintf(w, "bblfsh_driver_status{language=%s,status=%s} %d\n",
+			labelValue(d.Language), labelValue(string(d.Status)), d.Status.Rank())
+	}
+
+	fmt.Fprint(w, "# HELP bblfsh_driver_has_image Whether the driver has a published container image.\n")
+	fmt.Fprint(w, "# TYPE bblfsh_driver_has_image gauge\n")
+	for _, d := range list {
+		has := 0
+		if d.DockerhubURL != "" {
+			has = 1
+		}
+		fmt.Fprintf(w, "bblfsh_driver_has_image{language=%s} %d\n", labelValue(d.Language), has)
+	}
+
+	enrichErrors.Lock()
+	sources := make([]string, 0, len(enrichErrors.count))
+	for s := range enrichErrors.count {
+		sources = append(sources, s)
+	}
+	sort.Strings(sources)
+	fmt.Fprint(w, "# HELP bblfsh_enrichment_errors_total Failures to load the details of the drivers, by source.\n")
+	fmt.Fprint(w, "# TYPE bblfsh_enrichment_errors_total counter\n")
+	for _, s := range sources {
+		fmt.Fprintf(w, "bblfsh_enrichment_errors_total{source=%s} %d\n", labelValue(s), enrichErrors.count[s])
+	}
+	enrichErrors.Unlock()
+
+	fmt.Fprint(w, "# HELP bblfsh_drivers_updated_timestamp_seconds Last time the data of the drivers was loaded.\n")
+	fmt.Fprint(w, "# TYPE bblfsh_drivers_updated_timestamp_seconds gauge\n")
+	fmt.Fprintf(w, "bblfsh_drivers_updated_timestamp_seconds %d\n", updated.Unix())
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func labelValue(s string) string {
+	return `"` + labelEscaper.Replace(s) + `"`
+}
+
+// WriteMetricsFile writes the metrics to a file, replacing it atomically
+// so collectors never read a partial file.
+func WriteMetricsFile(path string, list []Driver) error {
+	return writeFileAtomic(path, func(w io.Writer) {
+		WriteMetrics(w, list, time.Now())
+	})
+}
+
+// writeFileAtomic writes a file with write, replacing it atomically.
+func writeFileAtomic(path string, write func(w io.Writer)) error {
+	tmp, err := ioutil.TempFile(filepath.Dir(path), ".metrics")
+	if err != nil {
+		return err
+	}
+	write(tmp)
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0644); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,53 @@
+package languages
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// notifyKinds are the kinds of changes worth a notification.
+var notifyKinds = map[string]bool{
+	ChangeNew:     true,
+	ChangeRemoved: true,
+	ChangeStatus:  true,
+	ChangeNoImage: true,
+	ChangeImage:   true,
+}
+
+// Notify posts a summary of the changes to a Slack-compatible incoming
+// webhook. Nothing is posted if there are no relevant changes.
+func Notify(url string, changes []Change) error {
+	var lines []string
+	for _, c := range changes {
+		if notifyKinds[c.Kind] {
+			lines = append(lines, "• "+c.Summary)
+		}
+	}
+	if len(lines) == 0 {
+		return nil
+	}
+
+	msg := struct {
+		Text string `json:"text"`
+	}{
+		Text: fmt.Sprintf("*Babelfish drivers changed* (%s)\n%s",
+			plural(len(lines), "change"), strings.Join(lines, "\n")),
+	}
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	cli := NewHTTPClient(time.Minute)
+	resp, err := cli.Post(url, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("notification failed: unexpected status: %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package languages
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"gopkg.in/bblfsh/sdk.v1/manifest"
+)
+
+// driverFlags are the per driver booleans of the OpenMetrics snapshot.
+var driverFlags = []struct {
+	Name, Help string
+	Value      func(d Driver) bool
+}{
+	{"bblfsh_driver_has_image", "Whether the driver has a published container image.",
+		func(d Driver) bool { return d.DockerhubURL != "" }},
+	{"bblfsh_driver_uast", "Whether the driver produces UASTs.",
+		func(d Driver) bool { return d.Supports(manifest.UAST) }},
+	{"bblfsh_driver_annotated", "Whether the driver annotates the UASTs with roles.",
+		func(d Driver) bool { return d.Supports(manifest.Roles) }},
+	{"bblfsh_driver_deprecated", "Whether the driver is deprecated.",
+		func(d Driver) bool { return d.Deprecated != "" }},
+}
+
+// WriteOpenMetrics writes a snapshot of the drivers in the OpenMetrics text
+// format, with every sample timestamped at the time of the snapshot, so it
+// can be backfilled into a time series database, like with promtool tsdb
+// create-blocks-from openmetrics. Unlike WriteMetrics, it has the number of
+// drivers by status and the booleans of every driver.
+func WriteOpenMetrics(w io.Writer, list []Driver, at time.Time) {
+	ts := fmt.Sprintf("%.3f", float64(at.UnixNano()/int64(time.Millisecond))/1e3)
+
+	byStatus := make(map[manifest.DevelopmentStatus]int)
+	var statuses []manifest.DevelopmentStatus
+	for _, d := range list {
+		if byStatus[d.Status] == 0 {
+			statuses = append(statuses, d.Status)
+		}
+		byStatus[d.Status]++
+	}
+	fmt.Fprint(w, "# TYPE bblfsh_drivers gauge\n")
+	fmt.Fprint(w, "# HELP bblfsh_drivers Number of drivers, by development status.\n")
+	for _, st := range statuses {
+		fmt.Fprintf(w, "bblfsh_drivers{status=%s} %d %s\n", labelValue(string(st)), byStatus[st], ts)
+	}
+
+	for _, f := range driverFlags {
+		fmt.Fprintf(w, "# TYPE %s gauge\n", f.Name)
+		fmt.Fprintf(w, "# HELP %s %s\n", f.Name, f.Help)
+		for _, d := range list {
+			v := 0
+			if f.Value(d) {
+				v = 1
+			}
+			fmt.Fprintf(w, "%s{language=%s} %d %s\n", f.Name, labelValue(d.Language), v, ts)
+		}
+	}
+	fmt.Fprint(w, "# EOF\n")
+}
+
+// WriteOpenMetricsFile writes an OpenMetrics snapshot of the drivers taken
+// now to a file, replacing it atomically.
+func WriteOpenMetricsFile(path string, list []Driver) error {
+	return writeFileAtomic(path, func(w io.Writer) {
+		WriteOpenMetrics(w, list, time.Now())
+	})
+}
@@ -0,0 +1,86 @@
+package languages
+
+import (
+	"fmt"
+	"io"
+	"sort"
+)
+
+// WriteOrgComparison writes a table comparing the drivers discovered from
+// two organizations, like upstream and a fork, with the drivers missing in
+// one of them or with a different version or status listed first.
+func WriteOrgComparison(w io.Writer, f Renderer, nameA string, a []Driver, nameB string, b []Driver) {
+	fmt.Fprint(w, f.Header())
+	defer fmt.Fprint(w, f.Footer())
+
+	byA, byB := driversByLanguage(a), driversByLanguage(b)
+	var langs []string
+	for lang := range byA {
+		langs = append(langs, lang)
+	}
+	for lang := range byB {
+		if _, ok := byA[lang]; !ok {
+			langs = append(langs, lang)
+		}
+	}
+	diverges := func(lang string) bool {
+		da, okA := byA[lang]
+		db, okB := byB[lang]
+		return !okA || !okB || orgVersion(da) != orgVersion(db) || da.Status != db.Status
+	}
+	sort.Slice(langs, func(i, j int) bool {
+		if di, dj := diverges(langs[i]), diverges(langs[j]); di != dj {
+			return di
+		}
+		return langs[i] < langs[j]
+	})
+
+	n := 0
+	rows := make([][]string, 0, len(langs))
+	for _, lang := range langs {
+		da, okA := byA[lang]
+		db, okB := byB[lang]
+		if diverges(lang) {
+			n++
+		}
+		row := []string{f.Text(lang)}
+		for _, d := range []struct {
+			d  Driver
+			ok bool
+		}{{da, okA}, {db, okB}} {
+			if !d.ok {
+				row = append(row, f.Text("-"), f.Text("-"))
+				continue
+			}
+			row = append(row, f.Link(orDash(orgVersion(d.d)), d.d.RepoURL()), f.Text(orDash(string(d.d.Status))))
+		}
+		row = append(row, f.Mark(!diverges(lang), ""))
+		rows = append(rows, row)
+	}
+
+	f.Heading(w, fmt.Sprintf("Drivers of %s and %s", nameA, nameB))
+	f.Paragraph(w, f.Text(fmt.Sprintf("%d of %s diverge.", n, plural(len(langs), "driver"))))
+	f.Table(w, []string{
+		"Language",
+		nameA + " version", nameA + " status",
+		nameB + " version", nameB + " status",
+		"In sync",
+	}, rows)
+}
+
+func driversByLanguage(list []Driver) map[string]Driver {
+	m := make(map[string]Driver, len(list))
+	for _, d := range list {
+		m[d.Language] = d
+	}
+	return m
+}
+
+// orgVersion returns the version of the driver to compare across
+// organizations: the one of the image or, without images, of the release.
+func orgVersion(d Driver) string {
+	if d.LatestVersion != "" {
+		return d.LatestVersion
+	}
+	return d.LatestRelease
+}
@@ -0,0 +1,79 @@
+package languages
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"gopkg.in/bblfsh/sdk.v1/manifest"
+	"gopkg.in/yaml.v2"
+)
+
+// overrideEntry is an entry of the overrides file, correcting the details
+// of a discovered driver. Empty fields keep the discovered value.
+type overrideEntry struct {
+	// Language selects the driver.
+	Language    string `yaml:"language"`
+	Name        string `yaml:"name"`
+	Status      string `yaml:"status"`
+	Description string `yaml:"description"`
+	// Documentation is the URL of the documentation of the driver.
+	Documentation string `yaml:"documentation"`
+	// Deprecated is a note explaining that the driver is deprecated.
+	Deprecated string `yaml:"deprecated"`
+}
+
+// Override is a detail of a driver replaced by the overrides file, kept
+// in the driver to track where its value comes from.
+type Override struct {
+	Field string
+	Value string
+	// Original is the discovered value, if any.
+	Original string `json:",omitempty"`
+	// Source is the overrides file.
+	Source string
+}
+
+// applyOverrides merges the entries of the overrides file over the
+// discovered drivers. Entries of unknown drivers are reported.
+func applyOverrides(path string, list []Driver, report func(Problem)) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var entries []overrideEntry
+	if err := yaml.Unmarshal(data, &entries); err != nil {
+		return fmt.Errorf("%s: %v", path, err)
+	}
+
+	byLang := make(map[string]*Driver, len(list))
+	for i := range list {
+		byLang[list[i].Language] = &list[i]
+	}
+	for _, e := range entries {
+		d := byLang[e.Language]
+		if d == nil {
+			report(Problem{File: path, Msg: fmt.Sprintf("override of unknown driver: %q", e.Language)})
+			continue
+		}
+		set := func(field, value string, dst *string) {
+			if value == "" {
+				return
+			}
+			d.Overrides = append(d.Overrides, Override{Field: field, Value: value, Original: *dst, Source: path})
+			*dst = value
+		}
+		set("Name", e.Name, &d.Name)
+		status := string(d.Status)
+		set("Status", e.Status, &status)
+		d.Status = manifest.DevelopmentStatus(status)
+		if e.Description != "" {
+			if d.Documentation == nil {
+				d.Documentation = &manifest.Documentation{}
+			}
+			set("Description", e.Description, &d.Documentation.Description)
+		}
+		set("DocumentationURL", e.Documentation, &d.DocumentationURL)
+		set("Deprecated", e.Deprecated, &d.Deprecated)
+	}
+	return nil
+}
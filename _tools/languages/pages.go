@@ -0,0 +1,165 @@
+package languages
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/bblfsh/sdk.v1/manifest"
+)
+
+// WritePages writes a detail page for each driver into dir, named after
+// the language of the driver. The pages are written in parallel.
+func WritePages(dir, ext string, f Renderer, list []Driver) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	tasks := make([]func() error, 0, len(list))
+	for _, d := range list {
+		d := d
+		tasks = append(tasks, func() error {
+			var buf bytes.Buffer
+			buf.WriteString(f.Header())
+			writePage(&buf, f, d)
+			buf.WriteString(f.Footer())
+
+			path := filepath.Join(dir, d.Language+"."+ext)
+			return ioutil.WriteFile(path, buf.Bytes(), 0644)
+		})
+	}
+	return RunParallel(0, tasks...)
+}
+
+// writePage writes the details of a driver, as declared in its manifest
+// and found during the enrichment.
+func writePage(w io.Writer, f Renderer, d Driver) {
+	name := d.Name
+	if name == "" {
+		name = d.Language
+	}
+	f.Heading(w, name)
+	if d.Deprecated != "" {
+		f.Paragraph(w, f.Badge("deprecated", colorRed)+" "+f.Text(d.Deprecated))
+	}
+	if doc := d.Documentation; doc != nil && doc.Description != "" {
+		f.Paragraph(w, f.Text(strings.TrimSpace(doc.Description)))
+	}
+
+	var features []string
+	for _, ft := range d.Features {
+		features = append(features, f.Text(string(ft)))
+	}
+	image := "-"
+	if d.Image != "" && d.DockerhubURL != "" {
+		image = f.Link(d.Image, d.DockerhubURL)
+	}
+	rows := [][]string{
+		{f.Text("Language key"), f.Text(d.Language)},
+		{f.Text("Aliases"), f.Text(orDash(strings.Join(d.Aliases, ", ")))},
+		{f.Text("File extensions"), f.Text(orDash(strings.Join(d.Extensions, ", ")))},
+		{f.Text("Status"), f.Text(orDash(string(d.Status)))},
+		{f.Text("Latest version"), f.Text(orDash(d.LatestVersion))},
+		{f.Text("Language versions"), f.Text(orDash(d.languageVersions()))},
+		{f.Text("Features"), orDash(strings.Join(features, ", "))},
+		{f.Text("Repository"), f.Link(d.RepoURL(), d.RepoURL())},
+		{f.Text("Documentation"), docLink(f, d)},
+		{f.Text("Container image"), image},
+		{f.Text("Image digest"), f.Text(orDash(d.Digest))},
+		{f.Text("Maintainers"), maintainerCell(f, d)},
+		{f.Text("Output examples"), examplesCell(f, d)},
+	}
+	f.Subheading(w, "Details")
+	f.Table(w, []string{"Property", "Value"}, rows)
+
+	if doc := d.Documentation; doc != nil && doc.Caveats != "" {
+		f.Subheading(w, "Caveats")
+		f.Paragraph(w, f.Text(strings.TrimSpace(doc.Caveats)))
+	}
+
+	f.Subheading(w, "Installation")
+	if d.DockerhubURL == "" {
+		f.Paragraph(w, f.Text("This driver has no published container image yet."))
+		return
+	}
+	f.Paragraph(w, f.Text("Install the driver in a running bblfshd container with:"))
+	f.Code(w, "sh", installCommand(d))
+	f.Paragraph(w, f.Text("Or, with docker-compose, install it when bblfshd starts:"))
+	f.Code(w, "yaml", composeFragment(d))
+	if d.Digest != "" {
+		f.Paragraph(w, f.Text(fmt.Sprintf("To deploy exactly the same image, pin the %s tag by its digest:", d.RecommendedTag())))
+		f.Code(w, "", d.Image+"@"+d.Digest)
+	}
+	if !d.Supports(manifest.UAST) {
+		f.Paragraph(w, f.Text("Note that this driver can only return the native AST."))
+	}
+
+	if d.UASTExample != "" {
+		f.Subheading(w, "UAST example")
+		f.Paragraph(w, f.Text("Parsing this sample file with the latest image of the driver:"))
+		f.Code(w, d.Language, d.Sample)
+		f.Paragraph(w, f.Text("returns the following UAST (truncated):"))
+		f.Code(w, "", d.UASTExample)
+	}
+
+	if p := d.Performance; p != nil {
+		f.Subheading(w, "Performance")
+		f.Paragraph(w, f.Text(fmt.Sprintf("Parsing a corpus of %s (%s) with the latest image of the driver:",
+			plural(p.Files, "file"), humanSize(p.Bytes))))
+		mem := "-"
+		if p.Memory != 0 {
+			mem = humanSize(p.Memory)
+		}
+		f.Table(w, []string{"Measure", "Value"}, [][]string{
+			{f.Text("Throughput"), f.Text(humanSize(int64(p.Throughput())) + "/s")},
+			{f.Text("Time per file"), f.Text((p.Duration / time.Duration(p.Files)).String())},
+			{f.Text("Memory"), f.Text(mem)},
+		})
+	}
+}
+
+// installCommand returns the command that installs the driver image in
+// bblfshd, pinned to the latest version if there is one.
+func installCommand(d Driver) string {
+	return fmt.Sprintf("docker exec -it bblfshd bblfshctl driver install %s %s:%s",
+		d.Language, d.Image, d.RecommendedTag())
+}
+
+// composeFragment returns a docker-compose file that runs bblfshd and
+// installs the driver in it, through the control socket shared by both
+// containers. The installation is retried until bblfshd is listening.
+func composeFragment(d Driver) string {
+	return fmt.Sprintf(`services:
+  bblfshd:
+    image: bblfsh/bblfshd
+    privileged: true
+    ports:
+      - "9432:9432"
+    volumes:
+      - bblfshd-drivers:/var/lib/bblfshd
+      - bblfshd-ctl:/var/run
+  install-%[1]s-driver:
+    image: bblfsh/bblfshd
+    depends_on:
+      - bblfshd
+    restart: on-failure
+    volumes:
+      - bblfshd-ctl:/var/run
+    entrypoint: ["bblfshctl", "driver", "install", "--update", "%[1]s", "%[2]s:%[3]s"]
+volumes:
+  bblfshd-drivers:
+  bblfshd-ctl:
+`, d.Language, d.Image, d.RecommendedTag())
+}
+
+// docLink links to the documentation of the driver, if it is known.
+func docLink(f Renderer, d Driver) string {
+	if d.DocumentationURL == "" {
+		return f.Text("-")
+	}
+	return f.Link(d.DocumentationURL, d.DocumentationURL)
+}
@@ -0,0 +1,55 @@
+package languages
+
+import (
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// Errors are the errors of tasks run in parallel.
+type Errors []error
+
+func (e Errors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// RunParallel runs the tasks with at most workers of them at a time, or one
+// per CPU if workers is not positive. All the tasks run even if some fail,
+// and their errors are returned as Errors, in the order of the tasks.
+func RunParallel(workers int, tasks ...func() error) error {
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	errs := make([]error, len(tasks))
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers && w < len(tasks); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				errs[i] = tasks[i]()
+			}
+		}()
+	}
+	for i := range tasks {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+
+	var out Errors
+	for _, err := range errs {
+		if err != nil {
+			out = append(out, err)
+		}
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
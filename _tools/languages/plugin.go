@@ -0,0 +1,85 @@
+package languages
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// PluginEnricher is an enricher implemented by an external program. The
+// program receives the JSON of a driver in its standard input, and writes
+// a JSON object of extra fields to its standard output, like:
+//
+//	{"Support tier": "gold"}
+//
+// The fields are added to Driver.Extra, and rendered as additional columns
+// by ExtraColumns.
+type PluginEnricher struct {
+	// Path is the program to run.
+	Path string
+}
+
+func (p PluginEnricher) Name() string {
+	return "plugin:" + filepath.Base(p.Path)
+}
+
+func (p PluginEnricher) Enrich(ctx context.Context, d *Driver) {
+	in, err := json.Marshal(d)
+	if err != nil {
+		enrichFailed("plugin", "cannot encode %s driver: %v", d.Language, err)
+		return
+	}
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, p.Path)
+	cmd.Stdin = bytes.NewReader(in)
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		enrichFailed("plugin", "%s failed for %s driver: %v: %s", p.Path, d.Language, err,
+			strings.TrimSpace(stderr.String()))
+		return
+	}
+	var fields map[string]string
+	if err := json.Unmarshal(out, &fields); err != nil {
+		enrichFailed("plugin", "%s returned invalid fields for %s driver: %v", p.Path, d.Language, err)
+		return
+	}
+	if len(fields) == 0 {
+		return
+	}
+	if d.Extra == nil {
+		d.Extra = make(map[string]string, len(fields))
+	}
+	for k, v := range fields {
+		d.Extra[k] = v
+	}
+}
+
+// ExtraColumns returns a column for each extra field of the drivers, as
+// added by the plugins, sorted by name.
+func ExtraColumns(list []Driver) []Column {
+	seen := make(map[string]bool)
+	var names []string
+	for _, d := range list {
+		for k := range d.Extra {
+			if !seen[k] {
+				seen[k] = true
+				names = append(names, k)
+			}
+		}
+	}
+	sort.Strings(names)
+
+	cols := make([]Column, 0, len(names))
+	for _, name := range names {
+		name := name
+		cols = append(cols, Column{Header: name, Cell: textCell(func(d Driver) string {
+			return orDash(d.Extra[name])
+		})})
+	}
+	return cols
+}
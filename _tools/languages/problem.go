@@ -0,0 +1,23 @@
+package languages
+
+import "fmt"
+
+// Problem is an issue found in a file of the documentation or in the data
+// of a driver.
+type Problem struct {
+	// File and Line locate the problem. They are empty if the problem is
+	// not related to a file or a line.
+	File string
+	Line int
+	Msg  string
+}
+
+func (p Problem) Error() string {
+	switch {
+	case p.File == "":
+		return p.Msg
+	case p.Line == 0:
+		return p.File + ": " + p.Msg
+	}
+	return fmt.Sprintf("%s:%d: %s", p.File, p.Line, p.Msg)
+}
@@ -0,0 +1,73 @@
+package languages
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// PullRequest is a pull request to open in GitHub.
+type PullRequest struct {
+	// Repo is the "owner/name" of the repository.
+	Repo string
+	// Base is the branch to merge into, and Head the one with the changes,
+	// already pushed.
+	Base, Head  string
+	Title, Body string
+}
+
+// OpenPullRequest opens the pull request in GitHub and returns its URL. It
+// needs a token with access to the repository in GITHUB_TOKEN.
+func OpenPullRequest(pr PullRequest) (string, error) {
+	data, err := json.Marshal(map[string]string{
+		"title": pr.Title,
+		"body":  pr.Body,
+		"base":  pr.Base,
+		"head":  pr.Head,
+	})
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequest("POST", githubAPI+"repos/"+pr.Repo+"/pulls", bytes.NewReader(data))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := newGithub().cli.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		return "", fmt.Errorf("cannot open pull request in %s: unexpected status: %s", pr.Repo, resp.Status)
+	}
+	var created struct {
+		URL string `json:"html_url"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&created); err != nil {
+		return "", err
+	}
+	return created.URL, nil
+}
+
+// PullRequestBody describes the updated files and the changes of the
+// drivers, in markdown.
+func PullRequestBody(files []string, changes []Change) string {
+	var b strings.Builder
+	b.WriteString("Regenerated by the languages tool.\n\n")
+	b.WriteString("Updated files:\n\n")
+	for _, f := range files {
+		fmt.Fprintf(&b, "- `%s`\n", f)
+	}
+	if len(changes) != 0 {
+		fmt.Fprintf(&b, "\nDriver changes (%s):\n\n", plural(len(changes), "change"))
+		for _, c := range changes {
+			fmt.Fprintf(&b, "- %s\n", c.Summary)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,91 @@
+package languages
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// qualityChecks is the number of checks done by ValidateDriver.
+const qualityChecks = 4
+
+// Quality is the result of the validation of the manifest of a driver.
+type Quality struct {
+	Driver Driver
+	// Problems describe the checks that failed.
+	Problems []string
+	// Score is the fraction of the checks that passed, from 0 to 1.
+	Score float64
+}
+
+// ValidateDriver checks that the manifest of a driver lists its
+// maintainers, status and features, and that its documentation, the
+// README of the repository, exists. The repository is not requested if
+// cli is nil.
+func ValidateDriver(cli *http.Client, d Driver) Quality {
+	q := Quality{Driver: d}
+	if len(d.Maintainers) == 0 {
+		q.Problems = append(q.Problems, "no maintainers")
+	}
+	if d.Status == "" {
+		q.Problems = append(q.Problems, "no status")
+	}
+	if len(d.Features) == 0 {
+		q.Problems = append(q.Problems, "no features")
+	}
+	if url := d.RepoURL(); url == "" {
+		q.Problems = append(q.Problems, "no repository")
+	} else if cli != nil {
+		if err := checkURL(cli, url); err != nil {
+			q.Problems = append(q.Problems, fmt.Sprintf("documentation not found: %v", err))
+		}
+	}
+	q.Score = float64(qualityChecks-len(q.Problems)) / qualityChecks
+	return q
+}
+
+// checkURL requests a URL, failing if it does not return a successful
+// status.
+func checkURL(cli *http.Client, url string) error {
+	resp, err := cli.Get(url)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("%s: %s", url, resp.Status)
+	}
+	return nil
+}
+
+// WriteQuality writes the driver quality report, with the drivers with
+// the lowest scores first.
+func WriteQuality(w io.Writer, f Renderer, qs []Quality) {
+	qs = append([]Quality{}, qs...)
+	sort.SliceStable(qs, func(i, j int) bool {
+		return qs[i].Score < qs[j].Score
+	})
+
+	rows := make([][]string, 0, len(qs))
+	for _, q := range qs {
+		color := colorGreen
+		switch {
+		case q.Score < 0.5:
+			color = colorRed
+		case q.Score < 1:
+			color = colorYellow
+		}
+		rows = append(rows, []string{
+			f.Link(q.Driver.Language, q.Driver.RepoURL()),
+			f.Badge(fmt.Sprintf("%.0f%%", q.Score*100), color),
+			f.Text(orDash(strings.Join(q.Problems, ", "))),
+		})
+	}
+
+	fmt.Fprint(w, f.Header())
+	defer fmt.Fprint(w, f.Footer())
+	f.Heading(w, "Driver quality")
+	f.Table(w, []string{"Language", "Score", "Problems"}, rows)
+}
@@ -0,0 +1,119 @@
+package languages
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Verbose logs details of the requests to the services, like the GitHub
+// quota left.
+var Verbose bool
+
+// githubReserve is the number of requests left in the GitHub quota below
+// which the client waits for the quota to be reset.
+const githubReserve = 5
+
+// githubTransport authenticates the requests to GitHub with the token in
+// GITHUB_TOKEN, if set, and tracks the quota of the API from the headers of
+// the responses, waiting for its reset before it is exhausted.
+type githubTransport struct {
+	token string
+	base  http.RoundTripper
+
+	mu        sync.Mutex
+	remaining int
+	reset     time.Time
+	// waiting is the reset the requests are waiting for, if any.
+	waiting time.Time
+}
+
+func newGithubTransport() *githubTransport {
+	return &githubTransport{
+		token:     os.Getenv("GITHUB_TOKEN"),
+		base:      newTransport(),
+		remaining: -1,
+	}
+}
+
+func (t *githubTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.wait(req.Context()); err != nil {
+		return nil, err
+	}
+	if t.token != "" {
+		// the request must not be modified by a RoundTripper
+		req = req.Clone(req.Context())
+		req.Header.Set("Authorization", "token "+t.token)
+	}
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	t.update(resp.Header)
+	return resp, nil
+}
+
+// wait sleeps until the quota is reset if there are too few requests left,
+// unless the request is cancelled or the run is shut down first. The other
+// requests wait at the same time, without holding the lock.
+func (t *githubTransport) wait(ctx context.Context) error {
+	t.mu.Lock()
+	if t.remaining < 0 || t.remaining > githubReserve {
+		t.mu.Unlock()
+		return nil
+	}
+	remaining, reset := t.remaining, t.reset
+	// logged once per reset, not by every request waiting for it
+	first := !t.waiting.Equal(reset)
+	t.waiting = reset
+	t.mu.Unlock()
+
+	if d := time.Until(reset); d > 0 {
+		if first {
+			log.Printf("GitHub quota almost exhausted (%d requests left), waiting %s for its reset",
+				remaining, d.Round(time.Second))
+			emit(Event{Kind: EventWait, Source: "github", Duration: d})
+		}
+		timer := time.NewTimer(d)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-shutdownCtx.Done():
+			return shutdownCtx.Err()
+		}
+	}
+
+	t.mu.Lock()
+	if t.reset.Equal(reset) {
+		// unknown until the next response
+		t.remaining = -1
+	}
+	t.mu.Unlock()
+	return nil
+}
+
+// update records the quota reported in the headers of a response. Only the
+// API reports it.
+func (t *githubTransport) update(h http.Header) {
+	remaining, err := strconv.Atoi(h.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return
+	}
+	reset, err := strconv.ParseInt(h.Get("X-RateLimit-Reset"), 10, 64)
+	if err != nil {
+		return
+	}
+	t.mu.Lock()
+	t.remaining, t.reset = remaining, time.Unix(reset, 0)
+	t.mu.Unlock()
+	if Verbose {
+		log.Printf("GitHub quota: %s requests left, reset at %s",
+			h.Get("X-RateLimit-Remaining"), time.Unix(reset, 0).Format(time.Kitchen))
+	}
+}
@@ -0,0 +1,128 @@
+package languages
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+
+	"gopkg.in/bblfsh/sdk.v1/manifest"
+)
+
+// tableFeatures are the features of the drivers, by the header of their
+// column in the Markdown tables, without the footnote marks.
+var tableFeatures = []struct {
+	Header  string
+	Feature manifest.Feature
+}{
+	{"AST", manifest.AST},
+	{"UAST", manifest.UAST},
+	{"Annotations", manifest.Roles},
+}
+
+// ReadDocumentDrivers reads the drivers listed in the tables of a Markdown
+// document written by WriteDocument. Only the details needed to find the
+// regressions are read: the key, status, features and whether there is a
+// container image. The tables without the Key and Status columns are
+// ignored.
+func ReadDocumentDrivers(data []byte) []Driver {
+	var (
+		list []Driver
+		cols map[string]int
+		prev string
+	)
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if !strings.HasPrefix(line, "|") {
+			cols, prev = nil, ""
+			continue
+		}
+		cells := tableCells(line)
+		switch {
+		case strings.HasPrefix(line, "| ---"):
+			// the separator follows the header
+			cols = make(map[string]int)
+			for i, h := range tableCells(prev) {
+				cols[strings.Trim(strings.Replace(h, `\`, "", -1), "*")] = i
+			}
+			if _, ok := cols["Status"]; !ok {
+				cols = nil
+			} else if _, ok := cols["Key"]; !ok {
+				cols = nil
+			}
+		case cols != nil:
+			cell := func(h string) string {
+				if i, ok := cols[h]; ok && i < len(cells) {
+					return mdUnescaper.Replace(cells[i])
+				}
+				return ""
+			}
+			d := Driver{}
+			d.Language = cell("Key")
+			d.Status = manifest.DevelopmentStatus(cell("Status"))
+			for _, ft := range tableFeatures {
+				if isYes(cell(ft.Header)) {
+					d.Features = append(d.Features, ft.Feature)
+				}
+			}
+			if c := cell("Container"); isYes(c) {
+				d.DockerhubURL = c
+			}
+			list = append(list, d)
+		}
+		prev = line
+	}
+	return list
+}
+
+// isYes reports whether a cell has a yes mark, as a symbol or as text,
+// maybe linked.
+func isYes(cell string) bool {
+	return strings.Contains(cell, boolIcon(true)) ||
+		strings.HasPrefix(strings.TrimPrefix(cell, "["), markText(true, true))
+}
+
+// mdUnescaper reverts the escaping of Markdown.Text, like the one of the
+// underscores of the keys.
+var mdUnescaper = strings.NewReplacer(`\\`, `\`, `\*`, `*`, `\_`, `_`, `\|`, `|`)
+
+// tableCells splits a row of a Markdown table into its cells.
+func tableCells(line string) []string {
+	line = strings.TrimSuffix(strings.TrimPrefix(line, "|"), "|")
+	cells := strings.Split(line, " | ")
+	for i, c := range cells {
+		cells[i] = strings.TrimSpace(c)
+	}
+	return cells
+}
+
+// regressionKinds are the kinds of changes that make a driver worse.
+var regressionKinds = map[string]bool{
+	ChangeRemoved:   true,
+	ChangeNoImage:   true,
+	ChangeNoUAST:    true,
+	ChangeNoFeature: true,
+}
+
+// Regressions returns the changes from the old list of drivers to the new
+// one that make them worse: removed drivers, images or features, and
+// downgraded statuses.
+func Regressions(old, cur []Driver) []Change {
+	prev := make(map[string]Driver, len(old))
+	for _, d := range old {
+		prev[d.Language] = d
+	}
+	status := make(map[string]manifest.DevelopmentStatus, len(cur))
+	for _, d := range cur {
+		status[d.Language] = d.Status
+	}
+
+	var out []Change
+	for _, c := range DiffDrivers(old, cur) {
+		if regressionKinds[c.Kind] ||
+			c.Kind == ChangeStatus && status[c.Language].Rank() < prev[c.Language].Status.Rank() {
+			out = append(out, c)
+		}
+	}
+	return out
+}
@@ -0,0 +1,91 @@
+package languages
+
+import (
+	"reflect"
+	"testing"
+
+	"gopkg.in/bblfsh/sdk.v1/manifest"
+)
+
+const regressionsDoc = `# Supported languages
+
+| Language | Key | Status | AST\* | UAST\*\* | Annotations\*\*\* | Container |
+| -------- | --- | ------ | ----- | -------- | ----------------- | --------- |
+| [Go](https://github.com/bblfsh/go-driver) | go | beta | ✓ | ✓ | ✓ | [✓](https://hub.docker.com/r/bblfsh/go-driver/) |
+| [Foo Bar](https://github.com/bblfsh/foo_bar-driver) | foo\_bar | alpha | yes | [yes](https://example.com) | no | [yes](https://hub.docker.com/r/bblfsh/foo_bar-driver/) |
+
+Not a driver table:
+
+| Name | Value |
+| ---- | ----- |
+| x | y |
+`
+
+func TestReadDocumentDrivers(t *testing.T) {
+	list := ReadDocumentDrivers([]byte(regressionsDoc))
+	if len(list) != 2 {
+		t.Fatalf("expected 2 drivers, got %d: %+v", len(list), list)
+	}
+	for i, exp := range []struct {
+		Language string
+		Status   manifest.DevelopmentStatus
+		Features []manifest.Feature
+		Image    string
+	}{
+		{"go", manifest.Beta, []manifest.Feature{manifest.AST, manifest.UAST, manifest.Roles},
+			"[✓](https://hub.docker.com/r/bblfsh/go-driver/)"},
+		{"foo_bar", manifest.Alpha, []manifest.Feature{manifest.AST, manifest.UAST},
+			"[yes](https://hub.docker.com/r/bblfsh/foo_bar-driver/)"},
+	} {
+		d := list[i]
+		if d.Language != exp.Language || d.Status != exp.Status || d.DockerhubURL != exp.Image {
+			t.Errorf("driver %d: got %q %q %q", i, d.Language, d.Status, d.DockerhubURL)
+		}
+		if !reflect.DeepEqual(d.Features, exp.Features) {
+			t.Errorf("%s: expected features %v, got %v", exp.Language, exp.Features, d.Features)
+		}
+	}
+}
+
+func TestRegressions(t *testing.T) {
+	old := ReadDocumentDrivers([]byte(regressionsDoc))
+
+	driver := func(lang string, st manifest.DevelopmentStatus, image bool, fts ...manifest.Feature) Driver {
+		var d Driver
+		d.Language, d.Status, d.Features = lang, st, fts
+		if image {
+			d.DockerhubURL = "https://hub.docker.com/r/bblfsh/" + lang + "-driver/"
+		}
+		return d
+	}
+
+	// the same drivers are no regression, even with the escaped key
+	same := []Driver{
+		driver("go", manifest.Beta, true, manifest.AST, manifest.UAST, manifest.Roles),
+		driver("foo_bar", manifest.Alpha, true, manifest.AST, manifest.UAST),
+	}
+	if regs := Regressions(old, same); len(regs) != 0 {
+		t.Errorf("expected no regressions, got %+v", regs)
+	}
+
+	// an upgrade and a new feature are no regression either
+	better := []Driver{
+		driver("go", manifest.Stable, true, manifest.AST, manifest.UAST, manifest.Roles),
+		driver("foo_bar", manifest.Alpha, true, manifest.AST, manifest.UAST, manifest.Roles),
+	}
+	if regs := Regressions(old, better); len(regs) != 0 {
+		t.Errorf("expected no regressions, got %+v", regs)
+	}
+
+	worse := []Driver{
+		driver("go", manifest.Alpha, true, manifest.AST, manifest.UAST, manifest.Roles),
+	}
+	kinds := make(map[string]string)
+	for _, c := range Regressions(old, worse) {
+		kinds[c.Language] = c.Kind
+	}
+	exp := map[string]string{"go": ChangeStatus, "foo_bar": ChangeRemoved}
+	if !reflect.DeepEqual(kinds, exp) {
+		t.Errorf("expected regressions %v, got %v", exp, kinds)
+	}
+}
@@ -0,0 +1,79 @@
+package languages
+
+import (
+	"encoding/json"
+	"fmt"
+	"os/exec"
+)
+
+// Vulnerabilities are the counts of known vulnerabilities of a driver
+// image, by severity.
+type Vulnerabilities struct {
+	Critical int
+	High     int
+}
+
+// trivyReport is the JSON report of a Trivy image scan. Older versions of
+// Trivy write the results alone, as an array.
+type trivyReport struct {
+	Results []trivyResult
+}
+
+type trivyResult struct {
+	Vulnerabilities []struct {
+		VulnerabilityID string
+		Severity        string
+	}
+}
+
+// loadVulnerabilities scans the latest image of the driver with Trivy,
+// that must be installed, and counts its high and critical vulnerabilities.
+// A failure is logged and leaves the counts empty.
+func loadVulnerabilities(d *Driver) {
+	if d.DockerhubURL == "" {
+		return
+	}
+	image := d.Image + ":" + d.RecommendedTag()
+	out, err := exec.Command("trivy", "image", "--quiet", "--format", "json",
+		"--severity", "HIGH,CRITICAL", image).Output()
+	if err != nil {
+		enrichFailed("trivy", "cannot scan %s: %v", image, err)
+		return
+	}
+	results, err := parseTrivy(out)
+	if err != nil {
+		enrichFailed("trivy", "cannot decode scan of %s: %v", image, err)
+		return
+	}
+
+	// the same vulnerability may be found in several layers or packages
+	seen := make(map[string]bool)
+	v := &Vulnerabilities{}
+	for _, r := range results {
+		for _, vuln := range r.Vulnerabilities {
+			if seen[vuln.VulnerabilityID] {
+				continue
+			}
+			seen[vuln.VulnerabilityID] = true
+			switch vuln.Severity {
+			case "CRITICAL":
+				v.Critical++
+			case "HIGH":
+				v.High++
+			}
+		}
+	}
+	d.Vulnerabilities = v
+}
+
+func parseTrivy(data []byte) ([]trivyResult, error) {
+	var rep trivyReport
+	if err := json.Unmarshal(data, &rep); err == nil {
+		return rep.Results, nil
+	}
+	var results []trivyResult
+	if err := json.Unmarshal(data, &results); err != nil {
+		return nil, fmt.Errorf("unexpected report: %v", err)
+	}
+	return results, nil
+}
@@ -0,0 +1,142 @@
+package languages
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+const githubRaw = "https://raw.githubusercontent.com/"
+
+// sdkModules are the import paths of the bblfsh SDK, by major version.
+var sdkModules = []string{
+	"gopkg.in/bblfsh/sdk.v1",
+	"gopkg.in/bblfsh/sdk.v2",
+	"github.com/bblfsh/sdk/v3",
+}
+
+// rawFile returns the content of a file in the repository at the given ref.
+func (g *githubClient) rawFile(repo, ref, path string) ([]byte, error) {
+	resp, err := g.cli.Get(githubRaw + repo + "/" + ref + "/" + path)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, errNotFound
+	} else if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s: unexpected status: %s", resp.Request.URL, resp.Status)
+	}
+	return ioutil.ReadAll(resp.Body)
+}
+
+// loadSDKVersion sets the version of the SDK the driver is built with, as
+// required by its go.mod or, for older drivers, locked in its Gopkg.lock,
+// and the protocols it implements.
+func (g *githubClient) loadSDKVersion(repo, branch string, d *Driver) error {
+	for _, dep := range []struct {
+		path  string
+		parse func(r io.Reader) string
+	}{
+		{"go.mod", sdkFromGoMod},
+		{"Gopkg.lock", sdkFromGopkgLock},
+	} {
+		data, err := g.rawFile(repo, branch, dep.path)
+		if err == errNotFound {
+			continue
+		} else if err != nil {
+			return err
+		}
+		if v := dep.parse(bytes.NewReader(data)); v != "" {
+			d.SDKVersion = v
+			d.Protocols = sdkProtocols(v)
+			return nil
+		}
+	}
+	return nil
+}
+
+// sdkFromGoMod finds the SDK version in the require directives of a go.mod.
+func sdkFromGoMod(r io.Reader) string {
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		fields := strings.Fields(strings.TrimPrefix(strings.TrimSpace(sc.Text()), "require "))
+		// lines in replace blocks are skipped, since they have an arrow
+		if len(fields) >= 2 && fields[1] != "=>" && isSDKModule(fields[0]) {
+			return fields[1]
+		}
+	}
+	return ""
+}
+
+// sdkFromGopkgLock finds the SDK version in the projects of a Gopkg.lock.
+func sdkFromGopkgLock(r io.Reader) string {
+	var inSDK bool
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "[[projects]]" {
+			inSDK = false
+			continue
+		}
+		i := strings.Index(line, "=")
+		if i < 0 {
+			continue
+		}
+		key := strings.TrimSpace(line[:i])
+		val := strings.Trim(strings.TrimSpace(line[i+1:]), `"`)
+		switch key {
+		case "name":
+			inSDK = isSDKModule(val)
+		case "version":
+			if inSDK {
+				return val
+			}
+		}
+	}
+	return ""
+}
+
+func isSDKModule(path string) bool {
+	for _, m := range sdkModules {
+		if path == m {
+			return true
+		}
+	}
+	return false
+}
+
+// sdkProtocols returns the protocols implemented by drivers built with the
+// given SDK version. SDK v1 drivers only implement the legacy protocol,
+// while newer ones implement v2 and keep serving the legacy one for the
+// older clients.
+func sdkProtocols(sdk string) []string {
+	v, ok := parseVersion(sdk)
+	switch {
+	case !ok:
+		return nil
+	case v[0] <= 1:
+		return []string{"v1"}
+	default:
+		return []string{"v1", "v2"}
+	}
+}
+
+// sortVersions sorts versions from the newest to the oldest. Versions that
+// cannot be parsed go last.
+func sortVersions(vers []string) {
+	sort.SliceStable(vers, func(i, j int) bool {
+		a, aok := parseVersion(vers[i])
+		b, bok := parseVersion(vers[j])
+		if aok != bok {
+			return aok
+		}
+		return b.Less(a)
+	})
+}
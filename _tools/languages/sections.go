@@ -0,0 +1,274 @@
+package languages
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/bblfsh/sdk.v1/manifest"
+)
+
+// writeTables writes the table of supported languages, followed by the
+// table of drivers still in development, the most requested languages, the
+// table of deprecated drivers and the table of community drivers, or a
+// table for each status of the official drivers if opts.GroupByStatus is
+// set. The official drivers are expected to be sorted by status, as
+// returned by the discovery.
+func writeTables(w io.Writer, f Renderer, list []Driver, cols []Column, opts *DocumentOptions) {
+	cols = opts.Statuses.columns(cols)
+	var official, community, deprecated []Driver
+	for _, d := range list {
+		switch {
+		case d.Community:
+			community = append(community, d)
+		case d.Deprecated != "":
+			deprecated = append(deprecated, d)
+		default:
+			official = append(official, d)
+		}
+	}
+
+	opts.Statuses.sort(official)
+
+	if opts.GroupByStatus {
+		writeStatusTables(w, f, official, cols, opts.Statuses)
+		if len(opts.Wanted) != 0 {
+			writeWanted(w, f, opts.Wanted)
+		}
+		if len(deprecated) != 0 {
+			f.Heading(w, fmt.Sprintf("Deprecated (%d)", len(deprecated)))
+			f.Table(w, headers(cols), tableRows(f, deprecated, cols))
+		}
+		if len(community) != 0 {
+			f.Heading(w, fmt.Sprintf("Community drivers (%d)", len(community)))
+			f.Table(w, headers(cols), tableRows(f, community, cols))
+		}
+		return
+	}
+
+	var supported, dev []Driver
+	for _, d := range official {
+		if opts.Statuses.supported(d.Status) {
+			supported = append(supported, d)
+		} else {
+			dev = append(dev, d)
+		}
+	}
+
+	f.Heading(w, "Supported languages")
+	f.Table(w, headers(cols), tableRows(f, supported, cols))
+
+	if len(dev) != 0 {
+		f.Heading(w, "In development")
+		f.Table(w, headers(cols), tableRows(f, dev, cols))
+	}
+	if len(opts.Wanted) != 0 {
+		writeWanted(w, f, opts.Wanted)
+	}
+	if len(deprecated) != 0 {
+		f.Heading(w, "Deprecated")
+		f.Table(w, headers(cols), tableRows(f, deprecated, cols))
+	}
+	if len(community) != 0 {
+		f.Heading(w, "Community drivers")
+		f.Table(w, headers(cols), tableRows(f, community, cols))
+	}
+}
+
+// writeStatusTables writes a table for each status of the drivers, with
+// the number of drivers in its heading. The drivers are expected to be
+// sorted by status.
+func writeStatusTables(w io.Writer, f Renderer, list []Driver, cols []Column, statuses StatusConfig) {
+	for len(list) != 0 {
+		n := 1
+		for n < len(list) && list[n].Status == list[0].Status {
+			n++
+		}
+		f.Heading(w, fmt.Sprintf("%s (%d)", statuses.title(list[0].Status), n))
+		f.Table(w, headers(cols), tableRows(f, list[:n], cols))
+		list = list[n:]
+	}
+}
+
+// statusTitle returns the status capitalized, to be used in headings.
+func statusTitle(st manifest.DevelopmentStatus) string {
+	if st == "" {
+		return "Unknown status"
+	}
+	return strings.ToUpper(string(st[:1])) + string(st[1:])
+}
+
+// writeSummary writes the number of drivers by status, with a published
+// image and with UAST support, like:
+//
+//	34 drivers: 12 beta, 9 alpha, 13 planning; 25 with published containers; 20 with UAST support.
+func writeSummary(w io.Writer, f Renderer, list []Driver, statuses StatusConfig) {
+	e := NewHistoryEntry(time.Time{}, list)
+	var byStatus []string
+	for _, st := range historyStatuses([]HistoryEntry{e}) {
+		name := statuses.label(manifest.DevelopmentStatus(st))
+		if name == "" {
+			name = "unknown status"
+		}
+		byStatus = append(byStatus, fmt.Sprintf("%d %s", e.Counts[st], name))
+	}
+
+	var images, uast int
+	for _, d := range list {
+		if d.DockerhubURL != "" {
+			images++
+		}
+		if d.Supports(manifest.UAST) {
+			uast++
+		}
+	}
+	text := plural(len(list), "driver")
+	if len(byStatus) != 0 {
+		text += ": " + strings.Join(byStatus, ", ")
+	}
+	text += fmt.Sprintf("; %d with published containers; %d with UAST support.", images, uast)
+	f.Paragraph(w, f.Text(text))
+}
+
+// dashboardSize is the number of drivers listed in the maintainer dashboard.
+const dashboardSize = 10
+
+// writeDashboard writes the drivers with the largest number of open issues
+// and pull requests.
+func writeDashboard(w io.Writer, f Renderer, list []Driver) {
+	list = append([]Driver{}, list...)
+	sort.SliceStable(list, func(i, j int) bool {
+		return list[i].backlog() > list[j].backlog()
+	})
+	if len(list) > dashboardSize {
+		list = list[:dashboardSize]
+	}
+
+	var rows [][]string
+	for _, d := range list {
+		if d.backlog() == 0 {
+			break
+		}
+		rows = append(rows, []string{
+			f.Link(d.Language, d.GithubURL),
+			f.Link(strconv.Itoa(d.OpenIssues), d.GithubURL+"/issues"),
+			f.Link(strconv.Itoa(d.OpenPullRequests), d.GithubURL+"/pulls"),
+			maintainerCell(f, d),
+		})
+	}
+
+	f.Heading(w, "Maintainer dashboard")
+	f.Table(w, []string{"Language", "Open issues", "Open pull requests", "Maintainers"}, rows)
+}
+
+// writeCompatibility writes the drivers built with each SDK version, and
+// the protocol those drivers implement.
+func writeCompatibility(w io.Writer, f Renderer, list []Driver) {
+	bySDK := make(map[string][]string)
+	var vers []string
+	for _, d := range list {
+		if d.SDKVersion == "" {
+			continue
+		}
+		if _, ok := bySDK[d.SDKVersion]; !ok {
+			vers = append(vers, d.SDKVersion)
+		}
+		bySDK[d.SDKVersion] = append(bySDK[d.SDKVersion], f.Link(d.Language, d.RepoURL()))
+	}
+	sortVersions(vers)
+
+	rows := make([][]string, 0, len(vers))
+	for _, v := range vers {
+		rows = append(rows, []string{
+			f.Text(v),
+			f.Text(orDash(strings.Join(sdkProtocols(v), ", "))),
+			strings.Join(bySDK[v], ", "),
+		})
+	}
+
+	f.Heading(w, "SDK compatibility")
+	f.Table(w, []string{"SDK version", "Protocol", "Drivers"}, rows)
+}
+
+// DocumentOptions selects the page written by WriteDocument and its
+// optional sections.
+type DocumentOptions struct {
+	// Page is either "languages", the default, or "maintainers".
+	Page string
+	// Columns are the columns of the tables. The default columns are used
+	// if it is empty.
+	Columns []Column
+	// Compat adds a compatibility matrix of drivers and SDK versions.
+	Compat bool
+	// Dashboard adds a maintainer dashboard with the drivers with the
+	// largest backlog.
+	Dashboard bool
+	// GroupByStatus writes a table for each status of the official
+	// drivers, instead of splitting them at alpha.
+	GroupByStatus bool
+	// Summary starts the page with the number of drivers by status, with
+	// a published image and with UAST support.
+	Summary bool
+	// Wanted adds the most requested languages after the drivers in
+	// development, as returned by LoadWanted.
+	Wanted []WantedLanguage
+	// HelpWanted adds the missing pieces of the drivers in development, as
+	// tasks linking to their repositories.
+	HelpWanted bool
+	// Metadata is written as a comment after the header, if set.
+	Metadata *Metadata
+	// Degraded is the reason the list of drivers may be incomplete, like
+	// a failed discovery, shown as a warning at the top of the page.
+	Degraded string
+	// History adds a trend section from these runs, in chronological
+	// order and ending with the current one.
+	History []HistoryEntry
+	// Statuses renames and orders the statuses of the drivers, and selects
+	// the ones listed as supported.
+	Statuses StatusConfig
+}
+
+// WriteDocument writes the page of the drivers selected in the options.
+func WriteDocument(w io.Writer, f Renderer, list []Driver, opts *DocumentOptions) {
+	if opts == nil {
+		opts = &DocumentOptions{}
+	}
+	fmt.Fprint(w, f.Header())
+	defer fmt.Fprint(w, f.Footer())
+	if opts.Metadata != nil {
+		fmt.Fprint(w, opts.Metadata.comment())
+	}
+	if opts.Degraded != "" {
+		f.Paragraph(w, f.Badge("degraded", colorYellow)+" "+f.Text("This list is a best effort: "+opts.Degraded+"."))
+	}
+
+	if opts.Page == "maintainers" {
+		writeMaintainers(w, f, list)
+		return
+	}
+
+	cols := opts.Columns
+	if len(cols) == 0 {
+		cols = defaultColumns
+	}
+	if opts.Summary {
+		writeSummary(w, f, list, opts.Statuses)
+	}
+	writeTables(w, f, list, cols, opts)
+	if opts.HelpWanted {
+		writeHelpWanted(w, f, list)
+	}
+	if opts.Compat {
+		writeCompatibility(w, f, list)
+	}
+	if opts.Dashboard {
+		writeDashboard(w, f, list)
+	}
+	if len(opts.History) != 0 {
+		writeTrend(w, f, opts.History)
+	}
+	fmt.Fprint(w, f.Legend())
+}
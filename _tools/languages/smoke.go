@@ -0,0 +1,33 @@
+package languages
+
+import (
+	"log"
+	"os/exec"
+)
+
+// smokeTest pulls the latest image of the driver and parses an empty file
+// with it, to check that the published image works, and not only that it
+// exists in the registry.
+func smokeTest(d *Driver) {
+	if d.DockerhubURL == "" {
+		return
+	}
+	image := d.Image + ":" + d.RecommendedTag()
+	if err := exec.Command("docker", "pull", "--quiet", image).Run(); err != nil {
+		// the image cannot be tested, which does not mean that it is broken
+		enrichFailed("smoke", "cannot pull %s: %v", image, err)
+		return
+	}
+
+	works := true
+	c, err := startDriver(image)
+	if err == nil {
+		_, err = c.waitParse(d.Language, "smoke-test", "")
+		c.stop()
+	}
+	if err != nil {
+		log.Printf("smoke test of %s failed: %v", image, err)
+		works = false
+	}
+	d.ImageWorks = &works
+}
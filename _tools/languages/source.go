@@ -0,0 +1,170 @@
+package languages
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+
+	"gopkg.in/bblfsh/sdk.v1/manifest"
+	"gopkg.in/bblfsh/sdk.v1/manifest/discovery"
+)
+
+const (
+	org = discovery.GithubOrg
+)
+
+// DriverSource lists the drivers found in a place, like a code hosting
+// service or a file.
+type DriverSource interface {
+	// Drivers returns the drivers of the source, with only the details of
+	// their manifests set.
+	Drivers(ctx context.Context) ([]Driver, error)
+}
+
+// OfficialSource lists the official drivers, from the bblfsh organization
+// in GitHub.
+type OfficialSource struct {
+	// Organization lists the drivers of another GitHub organization, like
+	// one with forks of the official drivers, with their images in the
+	// Docker Hub organization of the same name.
+	Organization string
+	// Fallback lists the drivers instead if the discovery in GitHub fails,
+	// like when it is rate-limited. The error is returned if it is nil.
+	Fallback DriverSource
+	// Degraded is called with the reason the drivers are listed from the
+	// fallback, to be shown in the document.
+	Degraded func(reason string)
+	// Ref pins the manifests to a commit, tag or branch of the driver
+	// repositories, instead of their default branch.
+	Ref string
+	// Report is called with the drivers without the Ref, that are skipped.
+	Report func(Problem)
+}
+
+func (s OfficialSource) Drivers(ctx context.Context) ([]Driver, error) {
+	owner := org
+	var dopts *discovery.Options
+	if s.Organization != "" {
+		owner = s.Organization
+		dopts = &discovery.Options{Organization: owner}
+	}
+	langs, err := discovery.OfficialDrivers(ctx, dopts)
+	if err != nil && s.Fallback != nil {
+		return fallback(ctx, s.Fallback, err, s.Degraded)
+	} else if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(langs))
+	for _, d := range langs {
+		names = append(names, d.Language)
+	}
+	log.Println(len(langs), "language drivers found:", names)
+
+	list := make([]Driver, 0, len(langs))
+	for _, d := range langs {
+		list = append(list, Driver{
+			Driver:      d,
+			Source:      sourceGithub,
+			GithubURL:   d.RepositoryURL(),
+			Image:       owner + `/` + d.Language + `-driver`,
+			Maintainers: newMaintainers(d.Maintainers),
+		})
+	}
+	if s.Organization != "" {
+		// the repositories are in the organization they were listed from
+		for i := range list {
+			list[i].GithubURL = `https://github.com/` + owner + `/` + list[i].Language + `-driver`
+		}
+	}
+	if s.Ref != "" {
+		return pinManifests(newGithub(), list, s.Ref, reporter(s.Report)), nil
+	}
+	return list, nil
+}
+
+// pinManifests replaces the manifests of the drivers with the ones at the
+// given ref of their repositories, recording the commit of each one. The
+// drivers without the ref, like the ones created after it, are reported
+// and skipped.
+func pinManifests(g *githubClient, list []Driver, ref string, report func(Problem)) []Driver {
+	out := list[:0]
+	for _, d := range list {
+		repo := repoPath(&d)
+		var c struct {
+			SHA string `json:"sha"`
+		}
+		if err := g.get("repos/"+repo+"/commits/"+ref, &c); err != nil {
+			report(Problem{Msg: fmt.Sprintf("%s: cannot resolve %s: %v", repo, ref, err)})
+			continue
+		}
+		data, err := g.rawFile(repo, c.SHA, "manifest.toml")
+		if err != nil {
+			report(Problem{Msg: fmt.Sprintf("%s: cannot get manifest at %s: %v", repo, ref, err)})
+			continue
+		}
+		var m manifest.Manifest
+		if err := m.Decode(bytes.NewReader(data)); err != nil {
+			report(Problem{Msg: fmt.Sprintf("%s: invalid manifest at %s: %v", repo, ref, err)})
+			continue
+		}
+		d.Manifest = m
+		d.Commit = c.SHA
+		setManifestLists(&d, data)
+		out = append(out, d)
+	}
+	return out
+}
+
+// GitlabSource lists the drivers of a GitLab group and its subgroups.
+type GitlabSource struct {
+	// URL is the GitLab instance, https://gitlab.com by default.
+	URL   string
+	Group string
+	// Token is the access token for private groups.
+	Token string
+	// Report is called with the drivers with an invalid manifest, that
+	// are skipped.
+	Report func(Problem)
+}
+
+func (s GitlabSource) Drivers(ctx context.Context) ([]Driver, error) {
+	url := s.URL
+	if url == "" {
+		url = "https://gitlab.com"
+	}
+	list, err := newGitlab(url, s.Token).Drivers(s.Group, reporter(s.Report))
+	if err != nil {
+		return nil, err
+	}
+	log.Println(len(list), "drivers found in GitLab group", s.Group)
+	return list, nil
+}
+
+// CommunitySource lists the community drivers of a YAML file.
+type CommunitySource struct {
+	Path string
+	// Report is called with the drivers with an invalid manifest, that
+	// are skipped.
+	Report func(Problem)
+}
+
+func (s CommunitySource) Drivers(ctx context.Context) ([]Driver, error) {
+	list, err := loadCommunity(newGithub(), s.Path, reporter(s.Report))
+	if err != nil {
+		return nil, err
+	}
+	log.Println(len(list), "community drivers found")
+	return list, nil
+}
+
+// reporter returns the function that reports problems, logging them if
+// it is nil.
+func reporter(report func(Problem)) func(Problem) {
+	if report != nil {
+		return report
+	}
+	return func(p Problem) {
+		log.Println(p)
+	}
+}
@@ -0,0 +1,75 @@
+package languages
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+// StaleImage is a driver image in Docker Hub that is not the image of any
+// discovered driver, like the ones of renamed or deleted drivers.
+type StaleImage struct {
+	Image string
+	Tags  []string
+	// LastPush is the last time the image was updated, if known.
+	LastPush *time.Time
+}
+
+// FindStaleImages returns the <org>/*-driver images in Docker Hub that are
+// not the image of any of the drivers, with their tags from reg, sorted by
+// name.
+func FindStaleImages(ctx context.Context, list []Driver, reg RegistryChecker) ([]StaleImage, error) {
+	if reg == nil {
+		reg = NewDockerHub()
+	}
+	repos, err := hubDriverRepos(ctx)
+	if err != nil {
+		return nil, err
+	}
+	known := make(map[string]bool, len(list))
+	for _, d := range list {
+		known[strings.ToLower(d.Image)] = true
+	}
+	var out []StaleImage
+	for _, r := range repos {
+		name := org + "/" + r.Name
+		if known[strings.ToLower(name)] {
+			continue
+		}
+		img := StaleImage{Image: name, LastPush: r.LastUpdated}
+		if img.Tags, err = reg.Tags(name); err != nil {
+			enrichFailed("docker", "cannot list tags of %s: %v", name, err)
+		}
+		sortVersions(img.Tags)
+		out = append(out, img)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Image < out[j].Image })
+	return out, nil
+}
+
+// WriteStaleImages writes the report of the driver images without a
+// driver, for the maintainers of the registry to clean them up.
+func WriteStaleImages(w io.Writer, f Renderer, imgs []StaleImage) {
+	fmt.Fprint(w, f.Header())
+	defer fmt.Fprint(w, f.Footer())
+
+	f.Heading(w, "Driver images without a driver")
+	if len(imgs) == 0 {
+		f.Paragraph(w, f.Text("Every driver image belongs to a discovered driver."))
+		return
+	}
+	f.Paragraph(w, f.Text(fmt.Sprintf("%s in Docker Hub do not belong to any discovered driver, like the ones of renamed or deleted drivers.",
+		plural(len(imgs), "image"))))
+	rows := make([][]string, 0, len(imgs))
+	for _, img := range imgs {
+		rows = append(rows, []string{
+			f.Link(img.Image, `https://hub.docker.com/r/`+img.Image+`/`),
+			f.Text(orDash(strings.Join(img.Tags, ", "))),
+			f.Text(formatDate(img.LastPush)),
+		})
+	}
+	f.Table(w, []string{"Image", "Tags", "Last push"}, rows)
+}
@@ -0,0 +1,136 @@
+package languages
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"gopkg.in/bblfsh/sdk.v1/manifest"
+	"gopkg.in/yaml.v2"
+)
+
+// StatusLabel is an entry of the statuses file, naming a development
+// status in the documents.
+type StatusLabel struct {
+	Status manifest.DevelopmentStatus `yaml:"status"`
+	// Label is the name shown for the status, the status itself if empty.
+	Label string `yaml:"label"`
+	// Supported lists the drivers with the status in the table of
+	// supported languages, instead of the one of drivers in development.
+	Supported bool `yaml:"supported"`
+}
+
+// StatusConfig renames and orders the development statuses of the drivers
+// in the documents, from the most to the least mature, and selects the
+// ones listed as supported, so the wording of the documents is not the
+// one of the SDK. The statuses not in the config go last. A nil config
+// uses the statuses of the SDK, with the supported ones from alpha.
+type StatusConfig []StatusLabel
+
+// ReadStatusConfig reads the statuses file at path. A file without
+// entries returns a nil config.
+func ReadStatusConfig(path string) (StatusConfig, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var c StatusConfig
+	if err := yaml.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	} else if len(c) == 0 {
+		return nil, nil
+	}
+	seen := make(map[manifest.DevelopmentStatus]bool, len(c))
+	for _, l := range c {
+		if seen[l.Status] {
+			return nil, fmt.Errorf("%s: duplicated status %q", path, l.Status)
+		}
+		seen[l.Status] = true
+	}
+	return c, nil
+}
+
+func (c StatusConfig) find(st manifest.DevelopmentStatus) (int, bool) {
+	for i, l := range c {
+		if l.Status == st {
+			return i, true
+		}
+	}
+	return len(c), false
+}
+
+// rank returns the maturity of the status, higher for the more mature.
+func (c StatusConfig) rank(st manifest.DevelopmentStatus) int {
+	if c == nil {
+		return st.Rank()
+	}
+	i, _ := c.find(st)
+	return len(c) - i
+}
+
+// supported reports whether the drivers with the status are listed as
+// supported.
+func (c StatusConfig) supported(st manifest.DevelopmentStatus) bool {
+	if c == nil {
+		return st.Rank() >= manifest.Alpha.Rank()
+	}
+	i, ok := c.find(st)
+	return ok && c[i].Supported
+}
+
+// label returns the name shown for the status.
+func (c StatusConfig) label(st manifest.DevelopmentStatus) string {
+	if i, ok := c.find(st); ok && c[i].Label != "" {
+		return c[i].Label
+	}
+	return string(st)
+}
+
+// title returns the name of the status to be used in headings.
+func (c StatusConfig) title(st manifest.DevelopmentStatus) string {
+	if i, ok := c.find(st); ok && c[i].Label != "" {
+		return c[i].Label
+	}
+	return statusTitle(st)
+}
+
+// sort sorts the drivers by the order of their statuses, keeping the order
+// of the drivers with the same status.
+func (c StatusConfig) sort(list []Driver) {
+	if c == nil {
+		// already sorted by the discovery
+		return
+	}
+	sort.SliceStable(list, func(i, j int) bool {
+		return c.rank(list[i].Status) > c.rank(list[j].Status)
+	})
+}
+
+// columns returns the columns with the Status one showing the labels.
+func (c StatusConfig) columns(cols []Column) []Column {
+	if c == nil {
+		return cols
+	}
+	out := make([]Column, len(cols))
+	for i, col := range cols {
+		if col.Header == "Status" {
+			col.Cell = textCell(func(d Driver) string { return c.label(d.Status) })
+		}
+		out[i] = col
+	}
+	return out
+}
+
+// Unlabel replaces the labels of the statuses of the drivers, like the ones
+// read by ReadDocumentDrivers, with the statuses they name.
+func (c StatusConfig) Unlabel(list []Driver) {
+	for i := range list {
+		for _, l := range c {
+			if l.Label != "" && strings.EqualFold(string(list[i].Status), l.Label) {
+				list[i].Status = l.Status
+				break
+			}
+		}
+	}
+}
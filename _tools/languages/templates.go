@@ -0,0 +1,57 @@
+package languages
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// Templates replace the content surrounding the generated sections, that
+// defaults to the one of the Renderer. Empty fields keep the default.
+type Templates struct {
+	Header string
+	Footer string
+	Legend string
+}
+
+// ReadTemplates reads the header, footer and legend of the given format
+// from dir, named like "header.md". Missing files keep the default.
+func ReadTemplates(dir, ext string) (Templates, error) {
+	var t Templates
+	for name, dst := range map[string]*string{
+		"header": &t.Header,
+		"footer": &t.Footer,
+		"legend": &t.Legend,
+	} {
+		data, err := ioutil.ReadFile(filepath.Join(dir, name+"."+ext))
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return t, err
+		}
+		*dst = string(data)
+	}
+	return t, nil
+}
+
+// WithTemplates returns a renderer that uses the given templates instead of
+// the header, footer and legend of f.
+func WithTemplates(f Renderer, t Templates) Renderer {
+	return templated{Renderer: f, t: t}
+}
+
+type templated struct {
+	Renderer
+	t Templates
+}
+
+func (r templated) Header() string { return orDefault(r.t.Header, r.Renderer.Header()) }
+func (r templated) Footer() string { return orDefault(r.t.Footer, r.Renderer.Footer()) }
+func (r templated) Legend() string { return orDefault(r.t.Legend, r.Renderer.Legend()) }
+
+func orDefault(s, def string) string {
+	if s == "" {
+		return def
+	}
+	return s
+}
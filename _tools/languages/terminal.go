@@ -0,0 +1,136 @@
+package languages
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"gopkg.in/bblfsh/sdk.v1/manifest"
+)
+
+// Terminal renders aligned plain text, to inspect the drivers in a
+// terminal. Links are reduced to their text.
+type Terminal struct {
+	// Color highlights the statuses, features and badges with ANSI escape
+	// codes.
+	Color bool
+	// PlainSymbols renders the marks as "yes" and "no".
+	PlainSymbols bool
+}
+
+// ansiColors are the ANSI escape codes of the badge colors.
+var ansiColors = map[string]string{
+	colorGreen:  "\x1b[32m",
+	colorYellow: "\x1b[33m",
+	colorRed:    "\x1b[31m",
+	colorGrey:   "\x1b[90m",
+}
+
+const ansiReset = "\x1b[0m"
+
+var ansiEscape = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+func (f Terminal) colored(s, color string) string {
+	code, ok := ansiColors[color]
+	if !f.Color || !ok {
+		return s
+	}
+	return code + s + ansiReset
+}
+
+// width returns the number of characters shown for s.
+func width(s string) int {
+	return utf8.RuneCountInString(ansiEscape.ReplaceAllString(s, ""))
+}
+
+func (Terminal) Header() string { return "" }
+func (Terminal) Footer() string { return "" }
+
+func (Terminal) Legend() string {
+	return "\n* native AST, ** UAST, *** annotated UAST\n"
+}
+
+func (Terminal) Heading(w io.Writer, title string) {
+	fmt.Fprintf(w, "\n%s\n%s\n", title, strings.Repeat("=", width(title)))
+}
+
+func (Terminal) Subheading(w io.Writer, title string) {
+	fmt.Fprintf(w, "\n%s\n%s\n", title, strings.Repeat("-", width(title)))
+}
+
+func (Terminal) Paragraph(w io.Writer, text string) {
+	fmt.Fprintf(w, "\n%s\n", text)
+}
+
+func (Terminal) Code(w io.Writer, lang, code string) {
+	fmt.Fprintln(w)
+	for _, line := range strings.Split(strings.TrimSuffix(code, "\n"), "\n") {
+		fmt.Fprintf(w, "    %s\n", line)
+	}
+}
+
+func (Terminal) Tasks(w io.Writer, items []string) {
+	fmt.Fprintln(w)
+	for _, it := range items {
+		fmt.Fprintf(w, "[ ] %s\n", it)
+	}
+}
+
+// Table writes the columns aligned. The statuses are colored by their
+// rank, and the check marks of the features in green and red.
+func (f Terminal) Table(w io.Writer, header []string, rows [][]string) {
+	status := -1
+	for i, h := range header {
+		if h == "Status" {
+			status = i
+		}
+	}
+	rows = append([][]string{header}, rows...)
+	widths := make([]int, len(header))
+	for _, row := range rows {
+		for i, c := range row {
+			if n := width(c); n > widths[i] {
+				widths[i] = n
+			}
+		}
+	}
+	fmt.Fprintln(w)
+	for r, row := range rows {
+		cells := make([]string, len(row))
+		for i, c := range row {
+			pad := strings.Repeat(" ", widths[i]-width(c))
+			switch {
+			case r == 0:
+			case i == status:
+				c = f.colored(c, statusColor(manifest.DevelopmentStatus(c)))
+			case c == f.Mark(true, ""):
+				c = f.colored(c, colorGreen)
+			case c == f.Mark(false, ""):
+				c = f.colored(c, colorRed)
+			}
+			cells[i] = c + pad
+		}
+		fmt.Fprintln(w, strings.TrimRight(strings.Join(cells, "  "), " "))
+		if r == 0 {
+			seps := make([]string, len(widths))
+			for i, n := range widths {
+				seps[i] = strings.Repeat("-", n)
+			}
+			fmt.Fprintln(w, strings.Join(seps, "  "))
+		}
+	}
+}
+
+func (Terminal) Text(s string) string { return s }
+
+func (Terminal) Link(text, url string) string { return text }
+
+func (Terminal) List(items []string) string { return strings.Join(items, ", ") }
+
+func (Terminal) Image(src, alt string) string { return "" }
+
+func (f Terminal) Badge(text, color string) string { return f.colored(text, color) }
+
+func (f Terminal) Mark(v bool, url string) string { return markText(v, f.PlainSymbols) }
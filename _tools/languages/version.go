@@ -0,0 +1,56 @@
+package languages
+
+import (
+	"strconv"
+	"strings"
+)
+
+// semver is a parsed major.minor.patch version. Pre-release and build
+// metadata are not supported, since tags carrying them are never
+// considered a release.
+type semver [3]int
+
+// parseVersion parses tags like "v1.2.3" or "1.2.3".
+func parseVersion(tag string) (semver, bool) {
+	var v semver
+	parts := strings.Split(strings.TrimPrefix(tag, "v"), ".")
+	if len(parts) != len(v) {
+		return v, false
+	}
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return v, false
+		}
+		v[i] = n
+	}
+	return v, true
+}
+
+func (v semver) Less(o semver) bool {
+	for i := range v {
+		if v[i] != o[i] {
+			return v[i] < o[i]
+		}
+	}
+	return false
+}
+
+// newestVersion returns the tag with the highest version, ignoring tags
+// that are not a version (like "latest" or "dev-1a2b3c").
+func newestVersion(tags []string) string {
+	var (
+		last  string
+		lastV semver
+	)
+	for _, t := range tags {
+		v, ok := parseVersion(t)
+		if !ok {
+			continue
+		}
+		if last == "" || lastV.Less(v) {
+			last, lastV = t, v
+		}
+	}
+	return last
+}
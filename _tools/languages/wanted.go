@@ -0,0 +1,67 @@
+package languages
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"sort"
+	"strconv"
+)
+
+// WantedLanguage is an issue requesting a driver for a new language.
+type WantedLanguage struct {
+	Title string
+	URL   string
+	// Reactions is the number of reactions to the issue, that ranks the
+	// requests.
+	Reactions int
+}
+
+// maxWantedPages is the number of pages of issues read by LoadWanted.
+const maxWantedPages = 10
+
+// LoadWanted returns the open issues of a GitHub repository with the given
+// label, like the requests of new languages, with the most reactions first.
+func LoadWanted(repo, label string) ([]WantedLanguage, error) {
+	const perPage = 100
+	g := newGithub()
+	var list []WantedLanguage
+	for page := 1; page <= maxWantedPages; page++ {
+		var issues []struct {
+			Title       string    `json:"title"`
+			URL         string    `json:"html_url"`
+			PullRequest *struct{} `json:"pull_request"`
+			Reactions   struct {
+				Total int `json:"total_count"`
+			} `json:"reactions"`
+		}
+		err := g.get(fmt.Sprintf("repos/%s/issues?state=open&labels=%s&per_page=%d&page=%d",
+			repo, url.QueryEscape(label), perPage, page), &issues)
+		if err != nil {
+			return nil, err
+		}
+		for _, is := range issues {
+			// pull requests are listed as issues too
+			if is.PullRequest == nil {
+				list = append(list, WantedLanguage{Title: is.Title, URL: is.URL, Reactions: is.Reactions.Total})
+			}
+		}
+		if len(issues) < perPage {
+			break
+		}
+	}
+	sort.SliceStable(list, func(i, j int) bool {
+		return list[i].Reactions > list[j].Reactions
+	})
+	return list, nil
+}
+
+// writeWanted writes the requested languages, ranked by reactions.
+func writeWanted(w io.Writer, f Renderer, list []WantedLanguage) {
+	rows := make([][]string, 0, len(list))
+	for _, l := range list {
+		rows = append(rows, []string{f.Link(l.Title, l.URL), f.Text(strconv.Itoa(l.Reactions))})
+	}
+	f.Heading(w, "Most requested languages")
+	f.Table(w, []string{"Request", "Reactions"}, rows)
+}